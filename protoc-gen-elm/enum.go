@@ -1,9 +1,13 @@
 package main
 
-import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+import (
+	"strconv"
 
-func (fg *FileGenerator) GenerateEnumDefinition(prefix string, inEnum *descriptor.EnumDescriptorProto) error {
-	typeName := prefix + inEnum.GetName()
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func (fg *FileGenerator) GenerateEnumDefinition(inEnum *descriptor.EnumDescriptorProto) error {
+	typeName := fg.types.names.enums[inEnum]
 	fg.P("")
 	fg.P("")
 	fg.P("type %s", typeName)
@@ -11,18 +15,30 @@ func (fg *FileGenerator) GenerateEnumDefinition(prefix string, inEnum *descripto
 		fg.In()
 		leading := "="
 		for _, enumValue := range inEnum.GetValue() {
-			// TODO: Convert names to CamelCase.
-			fg.P("%s %s -- %d", leading, prefix+elmEnumValueName(enumValue.GetName()), enumValue.GetNumber())
+			fg.P("%s %s -- %d", leading, fg.types.names.enumValues[enumValue], enumValue.GetNumber())
 			leading = "|"
 		}
+		if unrecognized, ok := fg.types.names.unrecognizedEnumValues[inEnum]; ok {
+			fg.P("%s %s String", leading, unrecognized)
+		}
 		fg.Out()
 	}
 	return nil
 }
 
-func (fg *FileGenerator) GenerateEnumDecoder(prefix string, inEnum *descriptor.EnumDescriptorProto) error {
-	typeName := prefix + inEnum.GetName()
+func (fg *FileGenerator) GenerateEnumDecoder(inEnum *descriptor.EnumDescriptorProto) error {
+	typeName := fg.types.names.enums[inEnum]
 	decoderName := decoderName(typeName)
+
+	// Closed enums (e.g. proto2 enums) reject unknown values; open enums (e.g. proto3 enums) accept
+	// them, and decode them according to the `unknown_enum_values` option. Unless they are decoded
+	// as the first value, open enums also accept numbers, so that unknown values can be kept.
+	closed := fg.features.enums[inEnum].enumType == descriptor.FeatureSet_CLOSED
+	unrecognized, preserve := fg.types.names.unrecognizedEnumValues[inEnum]
+	fail := closed || fg.options.UnknownEnumValues == unknownEnumValuesFail
+	numbers := !closed && fg.options.UnknownEnumValues != unknownEnumValuesFirst
+	lenient := !fail && !preserve
+
 	fg.P("")
 	fg.P("")
 	fg.P("%s : JD.Decoder %s", decoderName, typeName)
@@ -37,17 +53,44 @@ func (fg *FileGenerator) GenerateEnumDecoder(prefix string, inEnum *descriptor.E
 			fg.P("case s of")
 			{
 				fg.In()
+				cases := []string{}
+				values := []string{}
 				for _, enumValue := range inEnum.GetValue() {
-					fg.P("%q ->", enumValue.GetName())
+					cases = append(cases, enumValue.GetName())
+					values = append(values, fg.types.names.enumValues[enumValue])
+				}
+				if numbers {
+					// Aliases share the number of the first value.
+					seen := map[int32]bool{}
+					for _, enumValue := range inEnum.GetValue() {
+						if seen[enumValue.GetNumber()] {
+							continue
+						}
+						seen[enumValue.GetNumber()] = true
+						cases = append(cases, strconv.Itoa(int(enumValue.GetNumber())))
+						values = append(values, fg.types.names.enumValues[enumValue])
+					}
+				}
+				for i := range cases {
+					fg.P("%q ->", cases[i])
 					fg.In()
-					fg.P("%s", prefix+elmEnumValueName(enumValue.GetName()))
+					if lenient {
+						fg.P("%s", values[i])
+					} else {
+						fg.P("JD.succeed %s", values[i])
+					}
 					fg.P("")
 					fg.Out()
 				}
-				// TODO: This should fail instead.
 				fg.P("_ ->")
 				fg.In()
-				fg.P("%s", prefix+elmEnumValueName(inEnum.GetValue()[0].GetName()))
+				if fail {
+					fg.P("JD.fail <| \"unknown value for enum %s: \" ++ s", inEnum.GetName())
+				} else if preserve {
+					fg.P("JD.succeed <| %s s", unrecognized)
+				} else {
+					fg.P("%s", fg.types.names.enumValues[inEnum.GetValue()[0]])
+				}
 				fg.Out()
 				fg.Out()
 			}
@@ -57,7 +100,13 @@ func (fg *FileGenerator) GenerateEnumDecoder(prefix string, inEnum *descriptor.E
 		fg.P("in")
 		{
 			fg.In()
-			fg.P("JD.map lookup JD.string")
+			if lenient {
+				fg.P("JD.map lookup JD.string")
+			} else if numbers {
+				fg.P("enumNameOrNumberDecoder |> JD.andThen lookup")
+			} else {
+				fg.P("JD.string |> JD.andThen lookup")
+			}
 			fg.Out()
 		}
 		fg.Out()
@@ -67,12 +116,12 @@ func (fg *FileGenerator) GenerateEnumDecoder(prefix string, inEnum *descriptor.E
 	fg.P("")
 	fg.P("")
 	fg.P("%s : %s", defaultName, typeName)
-	fg.P("%s = %s", defaultName, prefix+elmEnumValueName(inEnum.GetValue()[0].GetName()))
+	fg.P("%s = %s", defaultName, fg.types.names.enumValues[inEnum.GetValue()[0]])
 	return nil
 }
 
-func (fg *FileGenerator) GenerateEnumEncoder(prefix string, inEnum *descriptor.EnumDescriptorProto) error {
-	typeName := prefix + inEnum.GetName()
+func (fg *FileGenerator) GenerateEnumEncoder(inEnum *descriptor.EnumDescriptorProto) error {
+	typeName := fg.types.names.enums[inEnum]
 	argName := "v"
 	fg.P("")
 	fg.P("")
@@ -89,12 +138,19 @@ func (fg *FileGenerator) GenerateEnumEncoder(prefix string, inEnum *descriptor.E
 			{
 				fg.In()
 				for _, enumValue := range inEnum.GetValue() {
-					fg.P("%s ->", prefix+elmEnumValueName(enumValue.GetName()))
+					fg.P("%s ->", fg.types.names.enumValues[enumValue])
 					fg.In()
 					fg.P("%q", enumValue.GetName())
 					fg.P("")
 					fg.Out()
 				}
+				if unrecognized, ok := fg.types.names.unrecognizedEnumValues[inEnum]; ok {
+					fg.P("%s x ->", unrecognized)
+					fg.In()
+					fg.P("x")
+					fg.P("")
+					fg.Out()
+				}
 				fg.Out()
 			}
 			fg.Out()
@@ -103,7 +159,11 @@ func (fg *FileGenerator) GenerateEnumEncoder(prefix string, inEnum *descriptor.E
 		fg.P("in")
 		{
 			fg.In()
-			fg.P("JE.string <| lookup %s", argName)
+			if _, ok := fg.types.names.unrecognizedEnumValues[inEnum]; ok {
+				fg.P("enumNameOrNumberEncoder <| lookup %s", argName)
+			} else {
+				fg.P("JE.string <| lookup %s", argName)
+			}
 			fg.Out()
 		}
 		fg.Out()
@@ -0,0 +1,106 @@
+package main
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+const (
+	// Range of editions accepted by the generator, advertised to protoc in the response.
+	minimumEdition = descriptor.Edition_EDITION_PROTO2
+	maximumEdition = descriptor.Edition_EDITION_2023
+)
+
+// featureSet holds the resolved values of the edition features that affect the generated code.
+//
+// proto2 and proto3 files are treated as the corresponding legacy editions, so the same rules
+// apply to all of them.
+type featureSet struct {
+	fieldPresence descriptor.FeatureSet_FieldPresence
+	enumType      descriptor.FeatureSet_EnumType
+}
+
+// fileFeatures holds the resolved features of each field and enum in a file.
+type fileFeatures struct {
+	fields map[*descriptor.FieldDescriptorProto]featureSet
+	enums  map[*descriptor.EnumDescriptorProto]featureSet
+}
+
+// fileEdition returns the edition of the file, mapping proto2 and proto3 syntax to the
+// corresponding legacy editions.
+func fileEdition(inFile *descriptor.FileDescriptorProto) descriptor.Edition {
+	switch fileSyntax(inFile) {
+	case "proto2":
+		return descriptor.Edition_EDITION_PROTO2
+	case "proto3":
+		return descriptor.Edition_EDITION_PROTO3
+	default:
+		return inFile.GetEdition()
+	}
+}
+
+// editionDefaults returns the default features of the given edition.
+func editionDefaults(edition descriptor.Edition) featureSet {
+	switch edition {
+	case descriptor.Edition_EDITION_PROTO2:
+		return featureSet{
+			fieldPresence: descriptor.FeatureSet_EXPLICIT,
+			enumType:      descriptor.FeatureSet_CLOSED,
+		}
+	case descriptor.Edition_EDITION_PROTO3:
+		return featureSet{
+			fieldPresence: descriptor.FeatureSet_IMPLICIT,
+			enumType:      descriptor.FeatureSet_OPEN,
+		}
+	default:
+		return featureSet{
+			fieldPresence: descriptor.FeatureSet_EXPLICIT,
+			enumType:      descriptor.FeatureSet_OPEN,
+		}
+	}
+}
+
+// merge overrides the features that are explicitly set in the given FeatureSet.
+func (f featureSet) merge(in *descriptor.FeatureSet) featureSet {
+	if in.GetFieldPresence() != descriptor.FeatureSet_FIELD_PRESENCE_UNKNOWN {
+		f.fieldPresence = in.GetFieldPresence()
+	}
+	if in.GetEnumType() != descriptor.FeatureSet_ENUM_TYPE_UNKNOWN {
+		f.enumType = in.GetEnumType()
+	}
+	return f
+}
+
+// resolveFeatures computes the features of every field and enum in the file, applying the
+// overrides set on the file, the enclosing messages and oneofs, and the element itself.
+func resolveFeatures(inFile *descriptor.FileDescriptorProto) *fileFeatures {
+	ff := &fileFeatures{
+		fields: map[*descriptor.FieldDescriptorProto]featureSet{},
+		enums:  map[*descriptor.EnumDescriptorProto]featureSet{},
+	}
+
+	f := editionDefaults(fileEdition(inFile)).merge(inFile.GetOptions().GetFeatures())
+	for _, inEnum := range inFile.GetEnumType() {
+		ff.enums[inEnum] = f.merge(inEnum.GetOptions().GetFeatures())
+	}
+	for _, inMessage := range inFile.GetMessageType() {
+		ff.resolveMessage(f, inMessage)
+	}
+
+	return ff
+}
+
+func (ff *fileFeatures) resolveMessage(parent featureSet, inMessage *descriptor.DescriptorProto) {
+	f := parent.merge(inMessage.GetOptions().GetFeatures())
+
+	for _, inField := range inMessage.GetField() {
+		fieldParent := f
+		if isOneofField(inField) {
+			fieldParent = f.merge(inMessage.GetOneofDecl()[inField.GetOneofIndex()].GetOptions().GetFeatures())
+		}
+		ff.fields[inField] = fieldParent.merge(inField.GetOptions().GetFeatures())
+	}
+	for _, inEnum := range inMessage.GetEnumType() {
+		ff.enums[inEnum] = f.merge(inEnum.GetOptions().GetFeatures())
+	}
+	for _, nested := range inMessage.GetNestedType() {
+		ff.resolveMessage(f, nested)
+	}
+}
@@ -10,13 +10,19 @@ type FileGenerator struct {
 	w io.Writer
 	// Used to avoid qualifying names in the same file.
 	inFileName string
+	types      typeIndex
+	features   *fileFeatures
+	options    *Options
 	indent     uint
 }
 
-func NewFileGenerator(w io.Writer, inFileName string) *FileGenerator {
+func NewFileGenerator(w io.Writer, inFileName string, types typeIndex, features *fileFeatures, options *Options) *FileGenerator {
 	return &FileGenerator{
 		w:          w,
 		inFileName: inFileName,
+		types:      types,
+		features:   features,
+		options:    options,
 	}
 }
 
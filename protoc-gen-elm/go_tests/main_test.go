@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -34,27 +35,51 @@ func TestDiff(t *testing.T) {
 			t.Fatal(err)
 		}
 
+		// Directories with an `expected_error` file contain invalid input, for which the plugin
+		// must report all the listed errors instead of generating any output.
+		expectedError, err := ioutil.ReadFile(filepath.Join(dir, "expected_error"))
+		if err == nil {
+			runProtoWithError(t, dir, string(expectedError))
+			continue
+		}
+
 		runProto(t, dir)
 		runDiff(t, dir)
 	}
 
 }
 
-func runProto(t *testing.T, dir string) {
+func protoCommand(t *testing.T, dir string) *exec.Cmd {
 	inputDir := filepath.Join(dir, "input")
 
-	args := []string{"--elm_out=../actual_output"}
+	// Directories with an `options` file run the plugin with the options it contains.
+	outDir := "../actual_output"
+	options, err := ioutil.ReadFile(filepath.Join(dir, "options"))
+	if err == nil {
+		outDir = strings.TrimSpace(string(options)) + ":" + outDir
+	}
+
+	args := []string{"--elm_out=" + outDir}
 	files, err := ioutil.ReadDir(inputDir)
 	if err != nil {
 		t.Fatalf("Error: %v", err)
 	}
 	for _, file := range files {
+		// Files in subdirectories are only available as imports, and are not generated.
+		if file.IsDir() {
+			continue
+		}
 		args = append(args, file.Name())
 	}
 
 	cmd := exec.Command("protoc", args...)
 	cmd.Dir = inputDir
 	t.Logf("cmd: %v", cmd)
+	return cmd
+}
+
+func runProto(t *testing.T, dir string) {
+	cmd := protoCommand(t, dir)
 	out, err := cmd.CombinedOutput()
 	t.Logf("Output: %s", out)
 	if err != nil {
@@ -62,6 +87,20 @@ func runProto(t *testing.T, dir string) {
 	}
 }
 
+func runProtoWithError(t *testing.T, dir string, expectedError string) {
+	cmd := protoCommand(t, dir)
+	out, err := cmd.CombinedOutput()
+	t.Logf("Output: %s", out)
+	if err == nil {
+		t.Fatalf("Expected protoc to fail in %s", dir)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(expectedError), "\n") {
+		if !strings.Contains(string(out), line) {
+			t.Errorf("Expected error %q not found in output", line)
+		}
+	}
+}
+
 func runDiff(t *testing.T, dir string) {
 	cmd := exec.Command("diff", "-y", "expected_output", "actual_output")
 	cmd.Dir = dir
@@ -0,0 +1,78 @@
+module Details exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: details.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Dict
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias BadRequest =
+    { fieldViolations : List BadRequest_FieldViolation -- 1
+    , metadata : Dict.Dict String String -- 2
+    }
+
+
+badRequestDecoder : JD.Decoder BadRequest
+badRequestDecoder =
+    JD.lazy <| \_ -> decode BadRequest
+        |> repeated "fieldViolations" badRequest_FieldViolationDecoder
+        |> mapEntries "metadata" JD.string
+
+
+badRequestEncoder : BadRequest -> JE.Value
+badRequestEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (repeatedFieldEncoder "fieldViolations" badRequest_FieldViolationEncoder v.fieldViolations)
+        , (mapEntriesFieldEncoder "metadata" JE.string v.metadata)
+        ]
+
+
+type alias BadRequest_FieldViolation =
+    { field : String -- 1
+    , description : String -- 2
+    }
+
+
+badRequest_FieldViolationDecoder : JD.Decoder BadRequest_FieldViolation
+badRequest_FieldViolationDecoder =
+    JD.lazy <| \_ -> decode BadRequest_FieldViolation
+        |> required "field" JD.string ""
+        |> required "description" JD.string ""
+
+
+badRequest_FieldViolationEncoder : BadRequest_FieldViolation -> JE.Value
+badRequest_FieldViolationEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "field" JE.string "" v.field)
+        , (requiredFieldEncoder "description" JE.string "" v.description)
+        ]
+
+
+type alias BadRequest_MetadataEntry =
+    { key : String -- 1
+    , value : String -- 2
+    }
+
+
+badRequest_MetadataEntryDecoder : JD.Decoder BadRequest_MetadataEntry
+badRequest_MetadataEntryDecoder =
+    JD.lazy <| \_ -> decode BadRequest_MetadataEntry
+        |> required "key" JD.string ""
+        |> required "value" JD.string ""
+
+
+badRequest_MetadataEntryEncoder : BadRequest_MetadataEntry -> JE.Value
+badRequest_MetadataEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.string "" v.key)
+        , (requiredFieldEncoder "value" JE.string "" v.value)
+        ]
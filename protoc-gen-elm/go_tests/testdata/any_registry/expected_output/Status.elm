@@ -0,0 +1,57 @@
+module Status exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: status.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Details
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Status =
+    { code : Int -- 1
+    , message : String -- 2
+    , details : List Any -- 3
+    }
+
+
+statusDecoder : JD.Decoder Status
+statusDecoder =
+    JD.lazy <| \_ -> decode Status
+        |> required "code" intDecoder 0
+        |> required "message" JD.string ""
+        |> repeated "details" anyDecoder
+
+
+statusEncoder : Status -> JE.Value
+statusEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "code" JE.int 0 v.code)
+        , (requiredFieldEncoder "message" JE.string "" v.message)
+        , (repeatedFieldEncoder "details" anyEncoder v.details)
+        ]
+
+
+type alias Status_Detail =
+    { reason : String -- 1
+    }
+
+
+status_DetailDecoder : JD.Decoder Status_Detail
+status_DetailDecoder =
+    JD.lazy <| \_ -> decode Status_Detail
+        |> required "reason" JD.string ""
+
+
+status_DetailEncoder : Status_Detail -> JE.Value
+status_DetailEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "reason" JE.string "" v.reason)
+        ]
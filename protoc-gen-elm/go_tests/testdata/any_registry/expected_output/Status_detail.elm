@@ -0,0 +1,32 @@
+module Status_detail exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: status_detail.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Detail =
+    { message : String -- 1
+    }
+
+
+detailDecoder : JD.Decoder Detail
+detailDecoder =
+    JD.lazy <| \_ -> decode Detail
+        |> required "message" JD.string ""
+
+
+detailEncoder : Detail -> JE.Value
+detailEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "message" JE.string "" v.message)
+        ]
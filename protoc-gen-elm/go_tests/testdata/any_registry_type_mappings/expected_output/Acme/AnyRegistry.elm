@@ -0,0 +1,166 @@
+module Acme.AnyRegistry exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Job
+import Acme.Duration
+
+
+type Message
+    = Acme_Jobs_Job Job.Job
+    | Google_Protobuf_Any Any
+    | Google_Protobuf_BoolValue Bool
+    | Google_Protobuf_BytesValue Bytes
+    | Google_Protobuf_DoubleValue Float
+    | Google_Protobuf_Duration Acme.Duration.Duration
+    | Google_Protobuf_Empty ()
+    | Google_Protobuf_FieldMask FieldMask
+    | Google_Protobuf_FloatValue Float
+    | Google_Protobuf_Int32Value Int
+    | Google_Protobuf_Int64Value Int
+    | Google_Protobuf_ListValue ListValue
+    | Google_Protobuf_StringValue String
+    | Google_Protobuf_Struct Struct
+    | Google_Protobuf_Timestamp Timestamp
+    | Google_Protobuf_UInt32Value Int
+    | Google_Protobuf_UInt64Value Int
+    | Google_Protobuf_Value Value
+    | Unknown Any
+
+
+unpack : Any -> Result JD.Error Message
+unpack v =
+    case anyTypeName v of
+        "acme.jobs.Job" ->
+            Result.map Acme_Jobs_Job <| anyUnpack Job.jobDecoder v
+
+        "google.protobuf.Any" ->
+            Result.map Google_Protobuf_Any <| anyUnpackWellKnown anyDecoder v
+
+        "google.protobuf.BoolValue" ->
+            Result.map Google_Protobuf_BoolValue <| anyUnpackWellKnown boolValueDecoder v
+
+        "google.protobuf.BytesValue" ->
+            Result.map Google_Protobuf_BytesValue <| anyUnpackWellKnown bytesValueDecoder v
+
+        "google.protobuf.DoubleValue" ->
+            Result.map Google_Protobuf_DoubleValue <| anyUnpackWellKnown floatValueDecoder v
+
+        "google.protobuf.Duration" ->
+            Result.map Google_Protobuf_Duration <| anyUnpackWellKnown Acme.Duration.durationDecoder v
+
+        "google.protobuf.Empty" ->
+            Result.map Google_Protobuf_Empty <| anyUnpack unitDecoder v
+
+        "google.protobuf.FieldMask" ->
+            Result.map Google_Protobuf_FieldMask <| anyUnpackWellKnown fieldMaskDecoder v
+
+        "google.protobuf.FloatValue" ->
+            Result.map Google_Protobuf_FloatValue <| anyUnpackWellKnown floatValueDecoder v
+
+        "google.protobuf.Int32Value" ->
+            Result.map Google_Protobuf_Int32Value <| anyUnpackWellKnown intValueDecoder v
+
+        "google.protobuf.Int64Value" ->
+            Result.map Google_Protobuf_Int64Value <| anyUnpackWellKnown intValueDecoder v
+
+        "google.protobuf.ListValue" ->
+            Result.map Google_Protobuf_ListValue <| anyUnpackWellKnown listValueDecoder v
+
+        "google.protobuf.StringValue" ->
+            Result.map Google_Protobuf_StringValue <| anyUnpackWellKnown stringValueDecoder v
+
+        "google.protobuf.Struct" ->
+            Result.map Google_Protobuf_Struct <| anyUnpackWellKnown structDecoder v
+
+        "google.protobuf.Timestamp" ->
+            Result.map Google_Protobuf_Timestamp <| anyUnpackWellKnown timestampDecoder v
+
+        "google.protobuf.UInt32Value" ->
+            Result.map Google_Protobuf_UInt32Value <| anyUnpackWellKnown intValueDecoder v
+
+        "google.protobuf.UInt64Value" ->
+            Result.map Google_Protobuf_UInt64Value <| anyUnpackWellKnown intValueDecoder v
+
+        "google.protobuf.Value" ->
+            Result.map Google_Protobuf_Value <| anyUnpackWellKnown valueDecoder v
+
+        _ ->
+            Ok <| Unknown v
+
+
+pack : Message -> Any
+pack message =
+    case message of
+        Acme_Jobs_Job v ->
+            anyPack "acme.jobs.Job" Job.jobEncoder v
+
+        Google_Protobuf_Any v ->
+            anyPackWellKnown "google.protobuf.Any" anyEncoder v
+
+        Google_Protobuf_BoolValue v ->
+            anyPackWellKnown "google.protobuf.BoolValue" boolValueEncoder v
+
+        Google_Protobuf_BytesValue v ->
+            anyPackWellKnown "google.protobuf.BytesValue" bytesValueEncoder v
+
+        Google_Protobuf_DoubleValue v ->
+            anyPackWellKnown "google.protobuf.DoubleValue" floatValueEncoder v
+
+        Google_Protobuf_Duration v ->
+            anyPackWellKnown "google.protobuf.Duration" Acme.Duration.durationEncoder v
+
+        Google_Protobuf_Empty v ->
+            anyPack "google.protobuf.Empty" unitEncoder v
+
+        Google_Protobuf_FieldMask v ->
+            anyPackWellKnown "google.protobuf.FieldMask" fieldMaskEncoder v
+
+        Google_Protobuf_FloatValue v ->
+            anyPackWellKnown "google.protobuf.FloatValue" floatValueEncoder v
+
+        Google_Protobuf_Int32Value v ->
+            anyPackWellKnown "google.protobuf.Int32Value" intValueEncoder v
+
+        Google_Protobuf_Int64Value v ->
+            anyPackWellKnown "google.protobuf.Int64Value" numericStringEncoder v
+
+        Google_Protobuf_ListValue v ->
+            anyPackWellKnown "google.protobuf.ListValue" listValueEncoder v
+
+        Google_Protobuf_StringValue v ->
+            anyPackWellKnown "google.protobuf.StringValue" stringValueEncoder v
+
+        Google_Protobuf_Struct v ->
+            anyPackWellKnown "google.protobuf.Struct" structEncoder v
+
+        Google_Protobuf_Timestamp v ->
+            anyPackWellKnown "google.protobuf.Timestamp" timestampEncoder v
+
+        Google_Protobuf_UInt32Value v ->
+            anyPackWellKnown "google.protobuf.UInt32Value" intValueEncoder v
+
+        Google_Protobuf_UInt64Value v ->
+            anyPackWellKnown "google.protobuf.UInt64Value" numericStringEncoder v
+
+        Google_Protobuf_Value v ->
+            anyPackWellKnown "google.protobuf.Value" valueEncoder v
+
+        Unknown v ->
+            v
+
+
+messageDecoder : JD.Decoder Message
+messageDecoder =
+    anyDecoder |> JD.andThen (unpack >> Result.mapError JD.errorToString >> fromResult)
+
+
+messageEncoder : Message -> JE.Value
+messageEncoder message =
+    anyEncoder <| pack message
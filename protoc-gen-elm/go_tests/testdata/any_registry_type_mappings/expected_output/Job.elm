@@ -0,0 +1,36 @@
+module Job exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: job.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Acme.Duration
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Job =
+    { name : String -- 1
+    , timeout : Maybe Acme.Duration.Duration -- 2
+    }
+
+
+jobDecoder : JD.Decoder Job
+jobDecoder =
+    JD.lazy <| \_ -> decode Job
+        |> required "name" JD.string ""
+        |> optional "timeout" Acme.Duration.durationDecoder
+
+
+jobEncoder : Job -> JE.Value
+jobEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        , (optionalEncoder "timeout" Acme.Duration.durationEncoder v.timeout)
+        ]
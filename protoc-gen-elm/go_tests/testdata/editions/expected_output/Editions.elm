@@ -0,0 +1,149 @@
+module Editions exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: editions.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type Open
+    = OpenUnspecified -- 0
+    | OpenValue -- 1
+
+
+openDecoder : JD.Decoder Open
+openDecoder =
+    let
+        lookup s =
+            case s of
+                "OPEN_UNSPECIFIED" ->
+                    OpenUnspecified
+
+                "OPEN_VALUE" ->
+                    OpenValue
+
+                _ ->
+                    OpenUnspecified
+    in
+        JD.map lookup JD.string
+
+
+openDefault : Open
+openDefault = OpenUnspecified
+
+
+openEncoder : Open -> JE.Value
+openEncoder v =
+    let
+        lookup s =
+            case s of
+                OpenUnspecified ->
+                    "OPEN_UNSPECIFIED"
+
+                OpenValue ->
+                    "OPEN_VALUE"
+
+    in
+        JE.string <| lookup v
+
+
+type Closed
+    = ClosedValue -- 1
+
+
+closedDecoder : JD.Decoder Closed
+closedDecoder =
+    let
+        lookup s =
+            case s of
+                "CLOSED_VALUE" ->
+                    JD.succeed ClosedValue
+
+                _ ->
+                    JD.fail <| "unknown value for enum Closed: " ++ s
+    in
+        JD.string |> JD.andThen lookup
+
+
+closedDefault : Closed
+closedDefault = ClosedValue
+
+
+closedEncoder : Closed -> JE.Value
+closedEncoder v =
+    let
+        lookup s =
+            case s of
+                ClosedValue ->
+                    "CLOSED_VALUE"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Explicit =
+    { explicitInt : Maybe Int -- 1
+    , explicitString : String -- 2
+    , implicitInt : Int -- 3
+    , requiredInt : Int -- 4
+    , open : Maybe Open -- 5
+    , closed : Maybe Closed -- 6
+    , repeatedInt : List Int -- 7
+    , requiredInner : Explicit_Inner -- 8
+    , optionalInner : Maybe Explicit_Inner -- 9
+    }
+
+
+explicitDecoder : JD.Decoder Explicit
+explicitDecoder =
+    JD.lazy <| \_ -> decode Explicit
+        |> optional "explicitInt" intDecoder
+        |> required "explicitString" JD.string "hello"
+        |> required "implicitInt" intDecoder 0
+        |> requiredStrict "requiredInt" intDecoder
+        |> optional "open" openDecoder
+        |> optional "closed" closedDecoder
+        |> repeated "repeatedInt" intDecoder
+        |> requiredStrict "requiredInner" explicit_InnerDecoder
+        |> optional "optionalInner" explicit_InnerDecoder
+
+
+explicitEncoder : Explicit -> JE.Value
+explicitEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "explicitInt" JE.int v.explicitInt)
+        , (requiredFieldEncoder "explicitString" JE.string "hello" v.explicitString)
+        , (requiredFieldEncoder "implicitInt" JE.int 0 v.implicitInt)
+        , (requiredStrictFieldEncoder "requiredInt" JE.int v.requiredInt)
+        , (optionalEncoder "open" openEncoder v.open)
+        , (optionalEncoder "closed" closedEncoder v.closed)
+        , (repeatedFieldEncoder "repeatedInt" JE.int v.repeatedInt)
+        , (requiredStrictFieldEncoder "requiredInner" explicit_InnerEncoder v.requiredInner)
+        , (optionalEncoder "optionalInner" explicit_InnerEncoder v.optionalInner)
+        ]
+
+
+type alias Explicit_Inner =
+    { name : Maybe String -- 1
+    }
+
+
+explicit_InnerDecoder : JD.Decoder Explicit_Inner
+explicit_InnerDecoder =
+    JD.lazy <| \_ -> decode Explicit_Inner
+        |> optional "name" JD.string
+
+
+explicit_InnerEncoder : Explicit_Inner -> JE.Value
+explicit_InnerEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "name" JE.string v.name)
+        ]
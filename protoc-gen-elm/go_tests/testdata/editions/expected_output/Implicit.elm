@@ -0,0 +1,56 @@
+module Implicit exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: implicit.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Implicit =
+    { implicitInt : Int -- 1
+    , explicitInt : Maybe Int -- 2
+    , other : Maybe Other -- 3
+    }
+
+
+implicitDecoder : JD.Decoder Implicit
+implicitDecoder =
+    JD.lazy <| \_ -> decode Implicit
+        |> required "implicitInt" intDecoder 0
+        |> optional "explicitInt" intDecoder
+        |> optional "other" otherDecoder
+
+
+implicitEncoder : Implicit -> JE.Value
+implicitEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "implicitInt" JE.int 0 v.implicitInt)
+        , (optionalEncoder "explicitInt" JE.int v.explicitInt)
+        , (optionalEncoder "other" otherEncoder v.other)
+        ]
+
+
+type alias Other =
+    { name : String -- 1
+    }
+
+
+otherDecoder : JD.Decoder Other
+otherDecoder =
+    JD.lazy <| \_ -> decode Other
+        |> required "name" JD.string ""
+
+
+otherEncoder : Other -> JE.Value
+otherEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        ]
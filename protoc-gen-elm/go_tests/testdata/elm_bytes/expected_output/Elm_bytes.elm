@@ -0,0 +1,68 @@
+module Elm_bytes exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: elm_bytes.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Bytes
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Blob =
+    { data : Bytes.Bytes -- 1
+    , chunks : List Bytes.Bytes -- 2
+    , maybeData : Maybe Bytes.Bytes -- 3
+    , payload : Blob_Payload
+    }
+
+
+type Blob_Payload
+    = Blob_PayloadUnspecified
+    | Blob_Raw Bytes.Bytes
+    | Blob_Text String
+
+
+blob_PayloadDecoder : JD.Decoder Blob_Payload
+blob_PayloadDecoder =
+    JD.lazy <| \_ -> JD.oneOf
+        [ JD.map Blob_Raw (JD.field "raw" elmBytesFieldDecoder)
+        , JD.map Blob_Text (JD.field "text" JD.string)
+        , JD.succeed Blob_PayloadUnspecified
+        ]
+
+
+blob_PayloadEncoder : Blob_Payload -> Maybe ( String, JE.Value )
+blob_PayloadEncoder v =
+    case v of
+        Blob_PayloadUnspecified ->
+            Nothing
+        Blob_Raw x ->
+            Just ( "raw", elmBytesFieldEncoder x )
+        Blob_Text x ->
+            Just ( "text", JE.string x )
+
+
+blobDecoder : JD.Decoder Blob
+blobDecoder =
+    JD.lazy <| \_ -> decode Blob
+        |> required "data" elmBytesFieldDecoder emptyElmBytes
+        |> repeated "chunks" elmBytesFieldDecoder
+        |> optional "maybeData" elmBytesValueDecoder
+        |> field blob_PayloadDecoder
+
+
+blobEncoder : Blob -> JE.Value
+blobEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredElmBytesFieldEncoder "data" elmBytesFieldEncoder emptyElmBytes v.data)
+        , (repeatedFieldEncoder "chunks" elmBytesFieldEncoder v.chunks)
+        , (optionalEncoder "maybeData" elmBytesValueEncoder v.maybeData)
+        , (blob_PayloadEncoder v.payload)
+        ]
@@ -0,0 +1,36 @@
+module Legacy_blob exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: legacy_blob.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Bytes
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias LegacyBlob =
+    { data : Bytes.Bytes -- 1
+    , maybeData : Maybe Bytes.Bytes -- 2
+    }
+
+
+legacyBlobDecoder : JD.Decoder LegacyBlob
+legacyBlobDecoder =
+    JD.lazy <| \_ -> decode LegacyBlob
+        |> required "data" elmBytesFieldDecoder (elmBytesFromList [ 1, 2 ])
+        |> optional "maybeData" elmBytesFieldDecoder
+
+
+legacyBlobEncoder : LegacyBlob -> JE.Value
+legacyBlobEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredElmBytesFieldEncoder "data" elmBytesFieldEncoder (elmBytesFromList [ 1, 2 ]) v.data)
+        , (optionalEncoder "maybeData" elmBytesFieldEncoder v.maybeData)
+        ]
@@ -0,0 +1,184 @@
+module Field_mask exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: field_mask.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Dict
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias UpdateUserRequest =
+    { user : Maybe User -- 1
+    , updateMask : Maybe FieldMask -- 2
+    }
+
+
+updateUserRequestDecoder : JD.Decoder UpdateUserRequest
+updateUserRequestDecoder =
+    JD.lazy <| \_ -> decode UpdateUserRequest
+        |> optional "user" userDecoder
+        |> optional "updateMask" fieldMaskDecoder
+
+
+updateUserRequestEncoder : UpdateUserRequest -> JE.Value
+updateUserRequestEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "user" userEncoder v.user)
+        , (optionalEncoder "updateMask" fieldMaskEncoder v.updateMask)
+        ]
+
+
+updateUserRequestPaths :
+    { user : String
+    , updateMask : String
+    }
+updateUserRequestPaths =
+    { user = "user"
+    , updateMask = "update_mask"
+    }
+
+
+type alias User =
+    { userId : String -- 1
+    , address : Maybe User_Address -- 2
+    , labels : Dict.Dict String String -- 3
+    , contact : User_Contact
+    }
+
+
+type User_Contact
+    = User_ContactUnspecified
+    | User_Email String
+    | User_Phone String
+
+
+user_ContactDecoder : JD.Decoder User_Contact
+user_ContactDecoder =
+    JD.lazy <| \_ -> JD.oneOf
+        [ JD.map User_Email (JD.field "email" JD.string)
+        , JD.map User_Phone (JD.field "phone" JD.string)
+        , JD.succeed User_ContactUnspecified
+        ]
+
+
+user_ContactEncoder : User_Contact -> Maybe ( String, JE.Value )
+user_ContactEncoder v =
+    case v of
+        User_ContactUnspecified ->
+            Nothing
+        User_Email x ->
+            Just ( "email", JE.string x )
+        User_Phone x ->
+            Just ( "phone", JE.string x )
+
+
+userDecoder : JD.Decoder User
+userDecoder =
+    JD.lazy <| \_ -> decode User
+        |> required "userId" JD.string ""
+        |> optional "address" user_AddressDecoder
+        |> mapEntries "labels" JD.string
+        |> field user_ContactDecoder
+
+
+userEncoder : User -> JE.Value
+userEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "userId" JE.string "" v.userId)
+        , (optionalEncoder "address" user_AddressEncoder v.address)
+        , (mapEntriesFieldEncoder "labels" JE.string v.labels)
+        , (user_ContactEncoder v.contact)
+        ]
+
+
+userPaths :
+    { userId : String
+    , address : String
+    , labels : String
+    , email : String
+    , phone : String
+    }
+userPaths =
+    { userId = "user_id"
+    , address = "address"
+    , labels = "labels"
+    , email = "email"
+    , phone = "phone"
+    }
+
+
+type alias User_Address =
+    { streetName : String -- 1
+    }
+
+
+user_AddressDecoder : JD.Decoder User_Address
+user_AddressDecoder =
+    JD.lazy <| \_ -> decode User_Address
+        |> required "streetName" JD.string ""
+
+
+user_AddressEncoder : User_Address -> JE.Value
+user_AddressEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "streetName" JE.string "" v.streetName)
+        ]
+
+
+user_AddressPaths :
+    { streetName : String
+    }
+user_AddressPaths =
+    { streetName = "street_name"
+    }
+
+
+type alias User_LabelsEntry =
+    { key : String -- 1
+    , value : String -- 2
+    }
+
+
+user_LabelsEntryDecoder : JD.Decoder User_LabelsEntry
+user_LabelsEntryDecoder =
+    JD.lazy <| \_ -> decode User_LabelsEntry
+        |> required "key" JD.string ""
+        |> required "value" JD.string ""
+
+
+user_LabelsEntryEncoder : User_LabelsEntry -> JE.Value
+user_LabelsEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.string "" v.key)
+        , (requiredFieldEncoder "value" JE.string "" v.value)
+        ]
+
+
+type alias Empty =
+    {
+    }
+
+
+emptyDecoder : JD.Decoder Empty
+emptyDecoder =
+    JD.lazy <| \_ -> decode Empty
+
+
+emptyEncoder : Empty -> JE.Value
+emptyEncoder v =
+    JE.object <| List.filterMap identity <|
+        [
+        ]
+
+
+emptyPaths : {}
+emptyPaths =
+    {}
@@ -0,0 +1,33 @@
+module Main exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: main.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Dep.Dep
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Main =
+    { dep : Maybe Dep.Dep.Dep -- 1
+    }
+
+
+mainDecoder : JD.Decoder Main
+mainDecoder =
+    JD.lazy <| \_ -> decode Main
+        |> optional "dep" Dep.Dep.depDecoder
+
+
+mainEncoder : Main -> JE.Value
+mainEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "dep" Dep.Dep.depEncoder v.dep)
+        ]
@@ -0,0 +1,81 @@
+module Int64_string exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: int64_string.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Dict
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Ids =
+    { id : Int64 -- 1
+    , unsignedId : UInt64 -- 2
+    , zigzagId : Int64 -- 3
+    , fixedId : UInt64 -- 4
+    , signedFixedId : Int64 -- 5
+    , relatedIds : List Int64 -- 6
+    , parentId : Maybe UInt64 -- 7
+    , maybeId : Maybe Int64 -- 8
+    , maybeUnsignedId : Maybe UInt64 -- 9
+    , counts : Dict.Dict String Int64 -- 10
+    }
+
+
+idsDecoder : JD.Decoder Ids
+idsDecoder =
+    JD.lazy <| \_ -> decode Ids
+        |> required "id" int64Decoder int64Zero
+        |> required "unsignedId" uint64Decoder uint64Zero
+        |> required "zigzagId" int64Decoder int64Zero
+        |> required "fixedId" uint64Decoder uint64Zero
+        |> required "signedFixedId" int64Decoder int64Zero
+        |> repeated "relatedIds" int64Decoder
+        |> optional "parentId" uint64Decoder
+        |> optional "maybeId" int64Decoder
+        |> optional "maybeUnsignedId" uint64Decoder
+        |> mapEntries "counts" int64Decoder
+
+
+idsEncoder : Ids -> JE.Value
+idsEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "id" int64Encoder int64Zero v.id)
+        , (requiredFieldEncoder "unsignedId" uint64Encoder uint64Zero v.unsignedId)
+        , (requiredFieldEncoder "zigzagId" int64Encoder int64Zero v.zigzagId)
+        , (requiredFieldEncoder "fixedId" uint64Encoder uint64Zero v.fixedId)
+        , (requiredFieldEncoder "signedFixedId" int64Encoder int64Zero v.signedFixedId)
+        , (repeatedFieldEncoder "relatedIds" int64Encoder v.relatedIds)
+        , (optionalEncoder "parentId" uint64Encoder v.parentId)
+        , (optionalEncoder "maybeId" int64Encoder v.maybeId)
+        , (optionalEncoder "maybeUnsignedId" uint64Encoder v.maybeUnsignedId)
+        , (mapEntriesFieldEncoder "counts" int64Encoder v.counts)
+        ]
+
+
+type alias Ids_CountsEntry =
+    { key : String -- 1
+    , value : Int64 -- 2
+    }
+
+
+ids_CountsEntryDecoder : JD.Decoder Ids_CountsEntry
+ids_CountsEntryDecoder =
+    JD.lazy <| \_ -> decode Ids_CountsEntry
+        |> required "key" JD.string ""
+        |> required "value" int64Decoder int64Zero
+
+
+ids_CountsEntryEncoder : Ids_CountsEntry -> JE.Value
+ids_CountsEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.string "" v.key)
+        , (requiredFieldEncoder "value" int64Encoder int64Zero v.value)
+        ]
@@ -0,0 +1,38 @@
+module Legacy_ids exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: legacy_ids.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias LegacyIds =
+    { min : Int64 -- 1
+    , max : UInt64 -- 2
+    , id : UInt64 -- 3
+    }
+
+
+legacyIdsDecoder : JD.Decoder LegacyIds
+legacyIdsDecoder =
+    JD.lazy <| \_ -> decode LegacyIds
+        |> required "min" int64Decoder (Maybe.withDefault int64Zero <| int64FromString "-9223372036854775808")
+        |> required "max" uint64Decoder (Maybe.withDefault uint64Zero <| uint64FromString "18446744073709551615")
+        |> requiredStrict "id" uint64Decoder
+
+
+legacyIdsEncoder : LegacyIds -> JE.Value
+legacyIdsEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "min" int64Encoder (Maybe.withDefault int64Zero <| int64FromString "-9223372036854775808") v.min)
+        , (requiredFieldEncoder "max" uint64Encoder (Maybe.withDefault uint64Zero <| uint64FromString "18446744073709551615") v.max)
+        , (requiredStrictFieldEncoder "id" uint64Encoder v.id)
+        ]
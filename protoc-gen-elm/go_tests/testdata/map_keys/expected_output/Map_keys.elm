@@ -0,0 +1,231 @@
+module Map_keys exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: map_keys.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Dict
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Value_ =
+    { name : String -- 1
+    }
+
+
+value_Decoder : JD.Decoder Value_
+value_Decoder =
+    JD.lazy <| \_ -> decode Value_
+        |> required "name" JD.string ""
+
+
+value_Encoder : Value_ -> JE.Value
+value_Encoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        ]
+
+
+type alias Outer =
+    { unsignedKeys : Dict.Dict Int Value_ -- 1
+    , signedKeys : Dict.Dict Int Int -- 2
+    , fixedKeys : Dict.Dict Int Bool -- 3
+    , stringKeys : Dict.Dict String Outer_Inner -- 4
+    , flags : BoolDict Value_ -- 5
+    }
+
+
+outerDecoder : JD.Decoder Outer
+outerDecoder =
+    JD.lazy <| \_ -> decode Outer
+        |> keyedMapEntries "unsignedKeys" String.toInt value_Decoder
+        |> keyedMapEntries "signedKeys" String.toInt intDecoder
+        |> keyedMapEntries "fixedKeys" String.toInt JD.bool
+        |> mapEntries "stringKeys" outer_InnerDecoder
+        |> boolMapEntries "flags" value_Decoder
+
+
+outerEncoder : Outer -> JE.Value
+outerEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (keyedMapEntriesFieldEncoder "unsignedKeys" String.fromInt value_Encoder v.unsignedKeys)
+        , (keyedMapEntriesFieldEncoder "signedKeys" String.fromInt numericStringEncoder v.signedKeys)
+        , (keyedMapEntriesFieldEncoder "fixedKeys" String.fromInt JE.bool v.fixedKeys)
+        , (mapEntriesFieldEncoder "stringKeys" outer_InnerEncoder v.stringKeys)
+        , (boolMapEntriesFieldEncoder "flags" value_Encoder v.flags)
+        ]
+
+
+type alias Outer_Inner =
+    { intKeys : Dict.Dict Int String -- 1
+    , boolKeys : BoolDict String -- 2
+    }
+
+
+outer_InnerDecoder : JD.Decoder Outer_Inner
+outer_InnerDecoder =
+    JD.lazy <| \_ -> decode Outer_Inner
+        |> keyedMapEntries "intKeys" String.toInt JD.string
+        |> boolMapEntries "boolKeys" JD.string
+
+
+outer_InnerEncoder : Outer_Inner -> JE.Value
+outer_InnerEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (keyedMapEntriesFieldEncoder "intKeys" String.fromInt JE.string v.intKeys)
+        , (boolMapEntriesFieldEncoder "boolKeys" JE.string v.boolKeys)
+        ]
+
+
+type alias Outer_Inner_IntKeysEntry =
+    { key : Int -- 1
+    , value : String -- 2
+    }
+
+
+outer_Inner_IntKeysEntryDecoder : JD.Decoder Outer_Inner_IntKeysEntry
+outer_Inner_IntKeysEntryDecoder =
+    JD.lazy <| \_ -> decode Outer_Inner_IntKeysEntry
+        |> required "key" intDecoder 0
+        |> required "value" JD.string ""
+
+
+outer_Inner_IntKeysEntryEncoder : Outer_Inner_IntKeysEntry -> JE.Value
+outer_Inner_IntKeysEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.int 0 v.key)
+        , (requiredFieldEncoder "value" JE.string "" v.value)
+        ]
+
+
+type alias Outer_Inner_BoolKeysEntry =
+    { key : Bool -- 1
+    , value : String -- 2
+    }
+
+
+outer_Inner_BoolKeysEntryDecoder : JD.Decoder Outer_Inner_BoolKeysEntry
+outer_Inner_BoolKeysEntryDecoder =
+    JD.lazy <| \_ -> decode Outer_Inner_BoolKeysEntry
+        |> required "key" JD.bool False
+        |> required "value" JD.string ""
+
+
+outer_Inner_BoolKeysEntryEncoder : Outer_Inner_BoolKeysEntry -> JE.Value
+outer_Inner_BoolKeysEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.bool False v.key)
+        , (requiredFieldEncoder "value" JE.string "" v.value)
+        ]
+
+
+type alias Outer_UnsignedKeysEntry =
+    { key : Int -- 1
+    , value : Maybe Value_ -- 2
+    }
+
+
+outer_UnsignedKeysEntryDecoder : JD.Decoder Outer_UnsignedKeysEntry
+outer_UnsignedKeysEntryDecoder =
+    JD.lazy <| \_ -> decode Outer_UnsignedKeysEntry
+        |> required "key" intDecoder 0
+        |> optional "value" value_Decoder
+
+
+outer_UnsignedKeysEntryEncoder : Outer_UnsignedKeysEntry -> JE.Value
+outer_UnsignedKeysEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.int 0 v.key)
+        , (optionalEncoder "value" value_Encoder v.value)
+        ]
+
+
+type alias Outer_SignedKeysEntry =
+    { key : Int -- 1
+    , value : Int -- 2
+    }
+
+
+outer_SignedKeysEntryDecoder : JD.Decoder Outer_SignedKeysEntry
+outer_SignedKeysEntryDecoder =
+    JD.lazy <| \_ -> decode Outer_SignedKeysEntry
+        |> required "key" intDecoder 0
+        |> required "value" intDecoder 0
+
+
+outer_SignedKeysEntryEncoder : Outer_SignedKeysEntry -> JE.Value
+outer_SignedKeysEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" numericStringEncoder 0 v.key)
+        , (requiredFieldEncoder "value" numericStringEncoder 0 v.value)
+        ]
+
+
+type alias Outer_FixedKeysEntry =
+    { key : Int -- 1
+    , value : Bool -- 2
+    }
+
+
+outer_FixedKeysEntryDecoder : JD.Decoder Outer_FixedKeysEntry
+outer_FixedKeysEntryDecoder =
+    JD.lazy <| \_ -> decode Outer_FixedKeysEntry
+        |> required "key" intDecoder 0
+        |> required "value" JD.bool False
+
+
+outer_FixedKeysEntryEncoder : Outer_FixedKeysEntry -> JE.Value
+outer_FixedKeysEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" numericStringEncoder 0 v.key)
+        , (requiredFieldEncoder "value" JE.bool False v.value)
+        ]
+
+
+type alias Outer_StringKeysEntry =
+    { key : String -- 1
+    , value : Maybe Outer_Inner -- 2
+    }
+
+
+outer_StringKeysEntryDecoder : JD.Decoder Outer_StringKeysEntry
+outer_StringKeysEntryDecoder =
+    JD.lazy <| \_ -> decode Outer_StringKeysEntry
+        |> required "key" JD.string ""
+        |> optional "value" outer_InnerDecoder
+
+
+outer_StringKeysEntryEncoder : Outer_StringKeysEntry -> JE.Value
+outer_StringKeysEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.string "" v.key)
+        , (optionalEncoder "value" outer_InnerEncoder v.value)
+        ]
+
+
+type alias Outer_FlagsEntry =
+    { key : Bool -- 1
+    , value : Maybe Value_ -- 2
+    }
+
+
+outer_FlagsEntryDecoder : JD.Decoder Outer_FlagsEntry
+outer_FlagsEntryDecoder =
+    JD.lazy <| \_ -> decode Outer_FlagsEntry
+        |> required "key" JD.bool False
+        |> optional "value" value_Decoder
+
+
+outer_FlagsEntryEncoder : Outer_FlagsEntry -> JE.Value
+outer_FlagsEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.bool False v.key)
+        , (optionalEncoder "value" value_Encoder v.value)
+        ]
@@ -0,0 +1,204 @@
+module Map_resolution exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: map_resolution.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Dict
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Other =
+    {
+    }
+
+
+otherDecoder : JD.Decoder Other
+otherDecoder =
+    JD.lazy <| \_ -> decode Other
+
+
+otherEncoder : Other -> JE.Value
+otherEncoder v =
+    JE.object <| List.filterMap identity <|
+        [
+        ]
+
+
+type alias Other_ValuesEntry =
+    { name : String -- 1
+    }
+
+
+other_ValuesEntryDecoder : JD.Decoder Other_ValuesEntry
+other_ValuesEntryDecoder =
+    JD.lazy <| \_ -> decode Other_ValuesEntry
+        |> required "name" JD.string ""
+
+
+other_ValuesEntryEncoder : Other_ValuesEntry -> JE.Value
+other_ValuesEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        ]
+
+
+type alias Outer =
+    { values : Dict.Dict String String -- 1
+    , others : List Other_ValuesEntry -- 2
+    }
+
+
+outerDecoder : JD.Decoder Outer
+outerDecoder =
+    JD.lazy <| \_ -> decode Outer
+        |> mapEntries "values" JD.string
+        |> repeated "others" other_ValuesEntryDecoder
+
+
+outerEncoder : Outer -> JE.Value
+outerEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (mapEntriesFieldEncoder "values" JE.string v.values)
+        , (repeatedFieldEncoder "others" other_ValuesEntryEncoder v.others)
+        ]
+
+
+type alias Outer_ValuesEntry =
+    { key : String -- 1
+    , value : String -- 2
+    }
+
+
+outer_ValuesEntryDecoder : JD.Decoder Outer_ValuesEntry
+outer_ValuesEntryDecoder =
+    JD.lazy <| \_ -> decode Outer_ValuesEntry
+        |> required "key" JD.string ""
+        |> required "value" JD.string ""
+
+
+outer_ValuesEntryEncoder : Outer_ValuesEntry -> JE.Value
+outer_ValuesEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.string "" v.key)
+        , (requiredFieldEncoder "value" JE.string "" v.value)
+        ]
+
+
+type alias Outer_Middle =
+    {
+    }
+
+
+outer_MiddleDecoder : JD.Decoder Outer_Middle
+outer_MiddleDecoder =
+    JD.lazy <| \_ -> decode Outer_Middle
+
+
+outer_MiddleEncoder : Outer_Middle -> JE.Value
+outer_MiddleEncoder v =
+    JE.object <| List.filterMap identity <|
+        [
+        ]
+
+
+type alias Outer_Middle_Inner =
+    { deep : Dict.Dict Int Outer -- 1
+    }
+
+
+outer_Middle_InnerDecoder : JD.Decoder Outer_Middle_Inner
+outer_Middle_InnerDecoder =
+    JD.lazy <| \_ -> decode Outer_Middle_Inner
+        |> keyedMapEntries "deep" String.toInt outerDecoder
+
+
+outer_Middle_InnerEncoder : Outer_Middle_Inner -> JE.Value
+outer_Middle_InnerEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (keyedMapEntriesFieldEncoder "deep" String.fromInt outerEncoder v.deep)
+        ]
+
+
+type alias Outer_Middle_Inner_DeepEntry =
+    { key : Int -- 1
+    , value : Maybe Outer -- 2
+    }
+
+
+outer_Middle_Inner_DeepEntryDecoder : JD.Decoder Outer_Middle_Inner_DeepEntry
+outer_Middle_Inner_DeepEntryDecoder =
+    JD.lazy <| \_ -> decode Outer_Middle_Inner_DeepEntry
+        |> required "key" intDecoder 0
+        |> optional "value" outerDecoder
+
+
+outer_Middle_Inner_DeepEntryEncoder : Outer_Middle_Inner_DeepEntry -> JE.Value
+outer_Middle_Inner_DeepEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.int 0 v.key)
+        , (optionalEncoder "value" outerEncoder v.value)
+        ]
+
+
+type alias Wrapper =
+    {
+    }
+
+
+wrapperDecoder : JD.Decoder Wrapper
+wrapperDecoder =
+    JD.lazy <| \_ -> decode Wrapper
+
+
+wrapperEncoder : Wrapper -> JE.Value
+wrapperEncoder v =
+    JE.object <| List.filterMap identity <|
+        [
+        ]
+
+
+type alias Wrapper_Nested =
+    { counts : Dict.Dict String Int -- 1
+    }
+
+
+wrapper_NestedDecoder : JD.Decoder Wrapper_Nested
+wrapper_NestedDecoder =
+    JD.lazy <| \_ -> decode Wrapper_Nested
+        |> mapEntries "counts" intDecoder
+
+
+wrapper_NestedEncoder : Wrapper_Nested -> JE.Value
+wrapper_NestedEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (mapEntriesFieldEncoder "counts" JE.int v.counts)
+        ]
+
+
+type alias Wrapper_Nested_CountsEntry =
+    { key : String -- 1
+    , value : Int -- 2
+    }
+
+
+wrapper_Nested_CountsEntryDecoder : JD.Decoder Wrapper_Nested_CountsEntry
+wrapper_Nested_CountsEntryDecoder =
+    JD.lazy <| \_ -> decode Wrapper_Nested_CountsEntry
+        |> required "key" JD.string ""
+        |> required "value" intDecoder 0
+
+
+wrapper_Nested_CountsEntryEncoder : Wrapper_Nested_CountsEntry -> JE.Value
+wrapper_Nested_CountsEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.string "" v.key)
+        , (requiredFieldEncoder "value" JE.int 0 v.value)
+        ]
@@ -0,0 +1,40 @@
+module Acme.Billing.V1.Invoice exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: invoice.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Acme.Common.V1.Money
+import Acme.Legacy
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Invoice =
+    { id : String -- 1
+    , total : Maybe Acme.Common.V1.Money.Money -- 2
+    , note : Maybe Acme.Legacy.Note -- 3
+    }
+
+
+invoiceDecoder : JD.Decoder Invoice
+invoiceDecoder =
+    JD.lazy <| \_ -> decode Invoice
+        |> required "id" JD.string ""
+        |> optional "total" Acme.Common.V1.Money.moneyDecoder
+        |> optional "note" Acme.Legacy.noteDecoder
+
+
+invoiceEncoder : Invoice -> JE.Value
+invoiceEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "id" JE.string "" v.id)
+        , (optionalEncoder "total" Acme.Common.V1.Money.moneyEncoder v.total)
+        , (optionalEncoder "note" Acme.Legacy.noteEncoder v.note)
+        ]
@@ -0,0 +1,32 @@
+module Acme.Legacy exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: legacy.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Note =
+    { text : String -- 1
+    }
+
+
+noteDecoder : JD.Decoder Note
+noteDecoder =
+    JD.lazy <| \_ -> decode Note
+        |> required "text" JD.string ""
+
+
+noteEncoder : Note -> JE.Value
+noteEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "text" JE.string "" v.text)
+        ]
@@ -0,0 +1,32 @@
+module Plain exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: plain.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Plain =
+    { value : String -- 1
+    }
+
+
+plainDecoder : JD.Decoder Plain
+plainDecoder =
+    JD.lazy <| \_ -> decode Plain
+        |> required "value" JD.string ""
+
+
+plainEncoder : Plain -> JE.Value
+plainEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "value" JE.string "" v.value)
+        ]
@@ -0,0 +1,100 @@
+module Name_collisions exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: name_collisions.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Event =
+    { fooBar : Maybe String -- 3
+    , fooBar_1 : Maybe String -- 4
+    , kind : Event_Kind
+    }
+
+
+type Event_Kind
+    = Event_KindUnspecified
+    | Event_Click_2 Event_Click
+    | Event_Key String
+
+
+event_KindDecoder : JD.Decoder Event_Kind
+event_KindDecoder =
+    JD.lazy <| \_ -> JD.oneOf
+        [ JD.map Event_Click_2 (JD.field "click" event_ClickDecoder)
+        , JD.map Event_Key (JD.field "key" JD.string)
+        , JD.succeed Event_KindUnspecified
+        ]
+
+
+event_KindEncoder : Event_Kind -> Maybe ( String, JE.Value )
+event_KindEncoder v =
+    case v of
+        Event_KindUnspecified ->
+            Nothing
+        Event_Click_2 x ->
+            Just ( "click", event_ClickEncoder x )
+        Event_Key x ->
+            Just ( "key", JE.string x )
+
+
+eventDecoder : JD.Decoder Event
+eventDecoder =
+    JD.lazy <| \_ -> decode Event
+        |> optional "fooBar" JD.string
+        |> optional "fooBar" JD.string
+        |> field event_KindDecoder
+
+
+eventEncoder : Event -> JE.Value
+eventEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "fooBar" JE.string v.fooBar)
+        , (optionalEncoder "fooBar" JE.string v.fooBar_1)
+        , (event_KindEncoder v.kind)
+        ]
+
+
+type alias Event_Click =
+    { x : Maybe Int -- 1
+    }
+
+
+event_ClickDecoder : JD.Decoder Event_Click
+event_ClickDecoder =
+    JD.lazy <| \_ -> decode Event_Click
+        |> optional "x" intDecoder
+
+
+event_ClickEncoder : Event_Click -> JE.Value
+event_ClickEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "x" JE.int v.x)
+        ]
+
+
+type alias Event_Click_1 =
+    { target : Maybe String -- 1
+    }
+
+
+event_Click_1Decoder : JD.Decoder Event_Click_1
+event_Click_1Decoder =
+    JD.lazy <| \_ -> decode Event_Click_1
+        |> optional "target" JD.string
+
+
+event_Click_1Encoder : Event_Click_1 -> JE.Value
+event_Click_1Encoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "target" JE.string v.target)
+        ]
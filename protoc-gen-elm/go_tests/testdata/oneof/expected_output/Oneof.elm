@@ -15,73 +15,153 @@ uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
 
 
 type alias Foo =
-    { firstOneof : FirstOneof
-    , secondOneof : SecondOneof
+    { firstOneof : Foo_FirstOneof
+    , secondOneof : Foo_SecondOneof
     }
 
 
-type FirstOneof
-    = FirstOneofUnspecified
-    | StringField String
-    | IntField Int
+type Foo_FirstOneof
+    = Foo_FirstOneofUnspecified
+    | Foo_StringField String
+    | Foo_IntField Int
 
 
-firstOneofDecoder : JD.Decoder FirstOneof
-firstOneofDecoder =
+foo_FirstOneofDecoder : JD.Decoder Foo_FirstOneof
+foo_FirstOneofDecoder =
     JD.lazy <| \_ -> JD.oneOf
-        [ JD.map StringField (JD.field "stringField" JD.string)
-        , JD.map IntField (JD.field "intField" intDecoder)
-        , JD.succeed FirstOneofUnspecified
+        [ JD.map Foo_StringField (JD.field "stringField" JD.string)
+        , JD.map Foo_IntField (JD.field "intField" intDecoder)
+        , JD.succeed Foo_FirstOneofUnspecified
         ]
 
 
-firstOneofEncoder : FirstOneof -> Maybe ( String, JE.Value )
-firstOneofEncoder v =
+foo_FirstOneofEncoder : Foo_FirstOneof -> Maybe ( String, JE.Value )
+foo_FirstOneofEncoder v =
     case v of
-        FirstOneofUnspecified ->
+        Foo_FirstOneofUnspecified ->
             Nothing
-        StringField x ->
+        Foo_StringField x ->
             Just ( "stringField", JE.string x )
-        IntField x ->
+        Foo_IntField x ->
             Just ( "intField", JE.int x )
 
 
-type SecondOneof
-    = SecondOneofUnspecified
-    | BoolField Bool
-    | OtherStringField String
+type Foo_SecondOneof
+    = Foo_SecondOneofUnspecified
+    | Foo_BoolField Bool
+    | Foo_OtherStringField String
 
 
-secondOneofDecoder : JD.Decoder SecondOneof
-secondOneofDecoder =
+foo_SecondOneofDecoder : JD.Decoder Foo_SecondOneof
+foo_SecondOneofDecoder =
     JD.lazy <| \_ -> JD.oneOf
-        [ JD.map BoolField (JD.field "boolField" JD.bool)
-        , JD.map OtherStringField (JD.field "otherStringField" JD.string)
-        , JD.succeed SecondOneofUnspecified
+        [ JD.map Foo_BoolField (JD.field "boolField" JD.bool)
+        , JD.map Foo_OtherStringField (JD.field "otherStringField" JD.string)
+        , JD.succeed Foo_SecondOneofUnspecified
         ]
 
 
-secondOneofEncoder : SecondOneof -> Maybe ( String, JE.Value )
-secondOneofEncoder v =
+foo_SecondOneofEncoder : Foo_SecondOneof -> Maybe ( String, JE.Value )
+foo_SecondOneofEncoder v =
     case v of
-        SecondOneofUnspecified ->
+        Foo_SecondOneofUnspecified ->
             Nothing
-        BoolField x ->
+        Foo_BoolField x ->
             Just ( "boolField", JE.bool x )
-        OtherStringField x ->
+        Foo_OtherStringField x ->
             Just ( "otherStringField", JE.string x )
 
 
 fooDecoder : JD.Decoder Foo
 fooDecoder =
     JD.lazy <| \_ -> decode Foo
-        |> field firstOneofDecoder
-        |> field secondOneofDecoder
+        |> field foo_FirstOneofDecoder
+        |> field foo_SecondOneofDecoder
 
 
 fooEncoder : Foo -> JE.Value
 fooEncoder v =
     JE.object <| List.filterMap identity <|
-        [ (firstOneofEncoder v.firstOneof)
-        , (secondOneofEncoder v.secondOneof)
+        [ (foo_FirstOneofEncoder v.firstOneof)
+        , (foo_SecondOneofEncoder v.secondOneof)
+        ]
+
+
+type alias Bar =
+    { firstOneof : Bar_FirstOneof
+    }
+
+
+type Bar_FirstOneof
+    = Bar_FirstOneofUnspecified
+    | Bar_StringField String
+
+
+bar_FirstOneofDecoder : JD.Decoder Bar_FirstOneof
+bar_FirstOneofDecoder =
+    JD.lazy <| \_ -> JD.oneOf
+        [ JD.map Bar_StringField (JD.field "stringField" JD.string)
+        , JD.succeed Bar_FirstOneofUnspecified
+        ]
+
+
+bar_FirstOneofEncoder : Bar_FirstOneof -> Maybe ( String, JE.Value )
+bar_FirstOneofEncoder v =
+    case v of
+        Bar_FirstOneofUnspecified ->
+            Nothing
+        Bar_StringField x ->
+            Just ( "stringField", JE.string x )
+
+
+barDecoder : JD.Decoder Bar
+barDecoder =
+    JD.lazy <| \_ -> decode Bar
+        |> field bar_FirstOneofDecoder
+
+
+barEncoder : Bar -> JE.Value
+barEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (bar_FirstOneofEncoder v.firstOneof)
+        ]
+
+
+type alias Bar_Nested =
+    { firstOneof : Bar_Nested_FirstOneof
+    }
+
+
+type Bar_Nested_FirstOneof
+    = Bar_Nested_FirstOneofUnspecified
+    | Bar_Nested_StringField String
+
+
+bar_Nested_FirstOneofDecoder : JD.Decoder Bar_Nested_FirstOneof
+bar_Nested_FirstOneofDecoder =
+    JD.lazy <| \_ -> JD.oneOf
+        [ JD.map Bar_Nested_StringField (JD.field "stringField" JD.string)
+        , JD.succeed Bar_Nested_FirstOneofUnspecified
+        ]
+
+
+bar_Nested_FirstOneofEncoder : Bar_Nested_FirstOneof -> Maybe ( String, JE.Value )
+bar_Nested_FirstOneofEncoder v =
+    case v of
+        Bar_Nested_FirstOneofUnspecified ->
+            Nothing
+        Bar_Nested_StringField x ->
+            Just ( "stringField", JE.string x )
+
+
+bar_NestedDecoder : JD.Decoder Bar_Nested
+bar_NestedDecoder =
+    JD.lazy <| \_ -> decode Bar_Nested
+        |> field bar_Nested_FirstOneofDecoder
+
+
+bar_NestedEncoder : Bar_Nested -> JE.Value
+bar_NestedEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (bar_Nested_FirstOneofEncoder v.firstOneof)
         ]
@@ -0,0 +1,124 @@
+module Oneof_variants exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: oneof_variants.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Task =
+    { status : Task_Status -- 4
+    , state : Task_State
+    }
+
+
+type Task_State
+    = Task_StateUnspecified
+    | Task_State_Active Bool
+    | Task_State_Done String
+    | Task_State_Archived Task_Archived
+
+
+task_StateDecoder : JD.Decoder Task_State
+task_StateDecoder =
+    JD.lazy <| \_ -> JD.oneOf
+        [ JD.map Task_State_Active (JD.field "active" JD.bool)
+        , JD.map Task_State_Done (JD.field "done" JD.string)
+        , JD.map Task_State_Archived (JD.field "archived" task_ArchivedDecoder)
+        , JD.succeed Task_StateUnspecified
+        ]
+
+
+task_StateEncoder : Task_State -> Maybe ( String, JE.Value )
+task_StateEncoder v =
+    case v of
+        Task_StateUnspecified ->
+            Nothing
+        Task_State_Active x ->
+            Just ( "active", JE.bool x )
+        Task_State_Done x ->
+            Just ( "done", JE.string x )
+        Task_State_Archived x ->
+            Just ( "archived", task_ArchivedEncoder x )
+
+
+type Task_Status
+    = Task_Active -- 0
+    | Task_Done -- 1
+
+
+taskDecoder : JD.Decoder Task
+taskDecoder =
+    JD.lazy <| \_ -> decode Task
+        |> required "status" task_StatusDecoder task_StatusDefault
+        |> field task_StateDecoder
+
+
+task_StatusDecoder : JD.Decoder Task_Status
+task_StatusDecoder =
+    let
+        lookup s =
+            case s of
+                "ACTIVE" ->
+                    Task_Active
+
+                "DONE" ->
+                    Task_Done
+
+                _ ->
+                    Task_Active
+    in
+        JD.map lookup JD.string
+
+
+task_StatusDefault : Task_Status
+task_StatusDefault = Task_Active
+
+
+taskEncoder : Task -> JE.Value
+taskEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "status" task_StatusEncoder task_StatusDefault v.status)
+        , (task_StateEncoder v.state)
+        ]
+
+
+task_StatusEncoder : Task_Status -> JE.Value
+task_StatusEncoder v =
+    let
+        lookup s =
+            case s of
+                Task_Active ->
+                    "ACTIVE"
+
+                Task_Done ->
+                    "DONE"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Task_Archived =
+    { reason : String -- 1
+    }
+
+
+task_ArchivedDecoder : JD.Decoder Task_Archived
+task_ArchivedDecoder =
+    JD.lazy <| \_ -> decode Task_Archived
+        |> required "reason" JD.string ""
+
+
+task_ArchivedEncoder : Task_Archived -> JE.Value
+task_ArchivedEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "reason" JE.string "" v.reason)
+        ]
@@ -0,0 +1,240 @@
+module Proto2 exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: proto2.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type Colour
+    = Red -- 1
+    | Green -- 2
+    | Blue -- 3
+
+
+colourDecoder : JD.Decoder Colour
+colourDecoder =
+    let
+        lookup s =
+            case s of
+                "RED" ->
+                    JD.succeed Red
+
+                "GREEN" ->
+                    JD.succeed Green
+
+                "BLUE" ->
+                    JD.succeed Blue
+
+                _ ->
+                    JD.fail <| "unknown value for enum Colour: " ++ s
+    in
+        JD.string |> JD.andThen lookup
+
+
+colourDefault : Colour
+colourDefault = Red
+
+
+colourEncoder : Colour -> JE.Value
+colourEncoder v =
+    let
+        lookup s =
+            case s of
+                Red ->
+                    "RED"
+
+                Green ->
+                    "GREEN"
+
+                Blue ->
+                    "BLUE"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Legacy =
+    { requiredInt : Int -- 1
+    , requiredString : String -- 2
+    , requiredInner : Legacy_Inner -- 3
+    , optionalInt : Maybe Int -- 4
+    , optionalString : Maybe String -- 5
+    , optionalColour : Maybe Colour -- 6
+    , optionalInner : Maybe Legacy_Inner -- 7
+    , defaultInt : Int -- 8
+    , defaultUint : Int -- 9
+    , defaultDouble : Float -- 10
+    , defaultFloat : Float -- 11
+    , defaultNan : Float -- 12
+    , defaultBool : Bool -- 13
+    , defaultString : String -- 14
+    , defaultBytes : Bytes -- 15
+    , defaultColour : Colour -- 16
+    , defaultSize : Legacy_Size -- 17
+    , repeatedInt : List Int -- 18
+    , result : Maybe Legacy_Result -- 19
+    , choice : Legacy_Choice
+    }
+
+
+type Legacy_Choice
+    = Legacy_ChoiceUnspecified
+    | Legacy_ChoiceInt Int
+    | Legacy_ChoiceString String
+
+
+legacy_ChoiceDecoder : JD.Decoder Legacy_Choice
+legacy_ChoiceDecoder =
+    JD.lazy <| \_ -> JD.oneOf
+        [ JD.map Legacy_ChoiceInt (JD.field "choiceInt" intDecoder)
+        , JD.map Legacy_ChoiceString (JD.field "choiceString" JD.string)
+        , JD.succeed Legacy_ChoiceUnspecified
+        ]
+
+
+legacy_ChoiceEncoder : Legacy_Choice -> Maybe ( String, JE.Value )
+legacy_ChoiceEncoder v =
+    case v of
+        Legacy_ChoiceUnspecified ->
+            Nothing
+        Legacy_ChoiceInt x ->
+            Just ( "choiceInt", JE.int x )
+        Legacy_ChoiceString x ->
+            Just ( "choiceString", JE.string x )
+
+
+type Legacy_Size
+    = Legacy_Small -- 0
+    | Legacy_Large -- 1
+
+
+legacyDecoder : JD.Decoder Legacy
+legacyDecoder =
+    JD.lazy <| \_ -> decode Legacy
+        |> requiredStrict "requiredInt" intDecoder
+        |> requiredStrict "requiredString" JD.string
+        |> requiredStrict "requiredInner" legacy_InnerDecoder
+        |> optional "optionalInt" intDecoder
+        |> optional "optionalString" JD.string
+        |> optional "optionalColour" colourDecoder
+        |> optional "optionalInner" legacy_InnerDecoder
+        |> required "defaultInt" intDecoder (-42)
+        |> required "defaultUint" intDecoder 18
+        |> required "defaultDouble" floatDecoder (-1.5)
+        |> required "defaultFloat" floatDecoder (1 / 0)
+        |> required "defaultNan" floatDecoder (0 / 0)
+        |> required "defaultBool" JD.bool True
+        |> required "defaultString" JD.string "say \"hi\"\n"
+        |> required "defaultBytes" bytesFieldDecoder [ 97, 1, 255 ]
+        |> required "defaultColour" colourDecoder Green
+        |> required "defaultSize" legacy_SizeDecoder Legacy_Large
+        |> repeated "repeatedInt" intDecoder
+        |> optional "result" legacy_ResultDecoder
+        |> field legacy_ChoiceDecoder
+
+
+legacy_SizeDecoder : JD.Decoder Legacy_Size
+legacy_SizeDecoder =
+    let
+        lookup s =
+            case s of
+                "SMALL" ->
+                    JD.succeed Legacy_Small
+
+                "LARGE" ->
+                    JD.succeed Legacy_Large
+
+                _ ->
+                    JD.fail <| "unknown value for enum Size: " ++ s
+    in
+        JD.string |> JD.andThen lookup
+
+
+legacy_SizeDefault : Legacy_Size
+legacy_SizeDefault = Legacy_Small
+
+
+legacyEncoder : Legacy -> JE.Value
+legacyEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredStrictFieldEncoder "requiredInt" JE.int v.requiredInt)
+        , (requiredStrictFieldEncoder "requiredString" JE.string v.requiredString)
+        , (requiredStrictFieldEncoder "requiredInner" legacy_InnerEncoder v.requiredInner)
+        , (optionalEncoder "optionalInt" JE.int v.optionalInt)
+        , (optionalEncoder "optionalString" JE.string v.optionalString)
+        , (optionalEncoder "optionalColour" colourEncoder v.optionalColour)
+        , (optionalEncoder "optionalInner" legacy_InnerEncoder v.optionalInner)
+        , (requiredFieldEncoder "defaultInt" JE.int (-42) v.defaultInt)
+        , (requiredFieldEncoder "defaultUint" numericStringEncoder 18 v.defaultUint)
+        , (requiredFieldEncoder "defaultDouble" floatEncoder (-1.5) v.defaultDouble)
+        , (requiredFieldEncoder "defaultFloat" floatEncoder (1 / 0) v.defaultFloat)
+        , (requiredFieldEncoder "defaultNan" floatEncoder (0 / 0) v.defaultNan)
+        , (requiredFieldEncoder "defaultBool" JE.bool True v.defaultBool)
+        , (requiredFieldEncoder "defaultString" JE.string "say \"hi\"\n" v.defaultString)
+        , (requiredFieldEncoder "defaultBytes" bytesFieldEncoder [ 97, 1, 255 ] v.defaultBytes)
+        , (requiredFieldEncoder "defaultColour" colourEncoder Green v.defaultColour)
+        , (requiredFieldEncoder "defaultSize" legacy_SizeEncoder Legacy_Large v.defaultSize)
+        , (repeatedFieldEncoder "repeatedInt" JE.int v.repeatedInt)
+        , (optionalEncoder "result" legacy_ResultEncoder v.result)
+        , (legacy_ChoiceEncoder v.choice)
+        ]
+
+
+legacy_SizeEncoder : Legacy_Size -> JE.Value
+legacy_SizeEncoder v =
+    let
+        lookup s =
+            case s of
+                Legacy_Small ->
+                    "SMALL"
+
+                Legacy_Large ->
+                    "LARGE"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Legacy_Inner =
+    { name : Maybe String -- 1
+    }
+
+
+legacy_InnerDecoder : JD.Decoder Legacy_Inner
+legacy_InnerDecoder =
+    JD.lazy <| \_ -> decode Legacy_Inner
+        |> optional "name" JD.string
+
+
+legacy_InnerEncoder : Legacy_Inner -> JE.Value
+legacy_InnerEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "name" JE.string v.name)
+        ]
+
+
+type alias Legacy_Result =
+    { url : Maybe String -- 20
+    }
+
+
+legacy_ResultDecoder : JD.Decoder Legacy_Result
+legacy_ResultDecoder =
+    JD.lazy <| \_ -> decode Legacy_Result
+        |> optional "url" JD.string
+
+
+legacy_ResultEncoder : Legacy_Result -> JE.Value
+legacy_ResultEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "url" JE.string v.url)
+        ]
@@ -0,0 +1,114 @@
+module Proto3_optional exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: proto3_optional.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type Status
+    = StatusUnspecified -- 0
+    | StatusActive -- 1
+
+
+statusDecoder : JD.Decoder Status
+statusDecoder =
+    let
+        lookup s =
+            case s of
+                "STATUS_UNSPECIFIED" ->
+                    StatusUnspecified
+
+                "STATUS_ACTIVE" ->
+                    StatusActive
+
+                _ ->
+                    StatusUnspecified
+    in
+        JD.map lookup JD.string
+
+
+statusDefault : Status
+statusDefault = StatusUnspecified
+
+
+statusEncoder : Status -> JE.Value
+statusEncoder v =
+    let
+        lookup s =
+            case s of
+                StatusUnspecified ->
+                    "STATUS_UNSPECIFIED"
+
+                StatusActive ->
+                    "STATUS_ACTIVE"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Optionals =
+    { maybeInt : Maybe Int -- 1
+    , maybeString : Maybe String -- 2
+    , maybeStatus : Maybe Status -- 3
+    , plainInt : Int -- 4
+    , maybeBool : Maybe Bool -- 7
+    , kind : Optionals_Kind
+    }
+
+
+type Optionals_Kind
+    = Optionals_KindUnspecified
+    | Optionals_KindInt Int
+    | Optionals_KindString String
+
+
+optionals_KindDecoder : JD.Decoder Optionals_Kind
+optionals_KindDecoder =
+    JD.lazy <| \_ -> JD.oneOf
+        [ JD.map Optionals_KindInt (JD.field "kindInt" intDecoder)
+        , JD.map Optionals_KindString (JD.field "kindString" JD.string)
+        , JD.succeed Optionals_KindUnspecified
+        ]
+
+
+optionals_KindEncoder : Optionals_Kind -> Maybe ( String, JE.Value )
+optionals_KindEncoder v =
+    case v of
+        Optionals_KindUnspecified ->
+            Nothing
+        Optionals_KindInt x ->
+            Just ( "kindInt", JE.int x )
+        Optionals_KindString x ->
+            Just ( "kindString", JE.string x )
+
+
+optionalsDecoder : JD.Decoder Optionals
+optionalsDecoder =
+    JD.lazy <| \_ -> decode Optionals
+        |> optional "maybeInt" intDecoder
+        |> optional "maybeString" JD.string
+        |> optional "maybeStatus" statusDecoder
+        |> required "plainInt" intDecoder 0
+        |> optional "maybeBool" JD.bool
+        |> field optionals_KindDecoder
+
+
+optionalsEncoder : Optionals -> JE.Value
+optionalsEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "maybeInt" JE.int v.maybeInt)
+        , (optionalEncoder "maybeString" JE.string v.maybeString)
+        , (optionalEncoder "maybeStatus" statusEncoder v.maybeStatus)
+        , (requiredFieldEncoder "plainInt" JE.int 0 v.plainInt)
+        , (optionalEncoder "maybeBool" JE.bool v.maybeBool)
+        , (optionals_KindEncoder v.kind)
+        ]
@@ -0,0 +1,41 @@
+module Main exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: main.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Accounts.User
+import Billing.All
+import Billing.User
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Customer =
+    { account : Maybe Accounts.User.User -- 1
+    , billing : Maybe Billing.User.User -- 2
+    , role : Accounts.User.Role -- 3
+    }
+
+
+customerDecoder : JD.Decoder Customer
+customerDecoder =
+    JD.lazy <| \_ -> decode Customer
+        |> optional "account" Accounts.User.userDecoder
+        |> optional "billing" Billing.User.userDecoder
+        |> required "role" Accounts.User.roleDecoder Accounts.User.RoleAdmin
+
+
+customerEncoder : Customer -> JE.Value
+customerEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "account" Accounts.User.userEncoder v.account)
+        , (optionalEncoder "billing" Billing.User.userEncoder v.billing)
+        , (requiredFieldEncoder "role" Accounts.User.roleEncoder Accounts.User.RoleAdmin v.role)
+        ]
@@ -118,8 +118,8 @@ type Foo_NestedEnum
 fooDecoder : JD.Decoder Foo
 fooDecoder =
     JD.lazy <| \_ -> decode Foo
-        |> required "doubleField" JD.float 0.0
-        |> required "floatField" JD.float 0.0
+        |> required "doubleField" floatDecoder 0.0
+        |> required "floatField" floatDecoder 0.0
         |> required "int32Field" intDecoder 0
         |> required "int64Field" intDecoder 0
         |> required "uint32Field" intDecoder 0
@@ -161,8 +161,8 @@ foo_NestedEnumDefault = Foo_EnumValueDefault
 fooEncoder : Foo -> JE.Value
 fooEncoder v =
     JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "doubleField" JE.float 0.0 v.doubleField)
-        , (requiredFieldEncoder "floatField" JE.float 0.0 v.floatField)
+        [ (requiredFieldEncoder "doubleField" floatEncoder 0.0 v.doubleField)
+        , (requiredFieldEncoder "floatField" floatEncoder 0.0 v.floatField)
         , (requiredFieldEncoder "int32Field" JE.int 0 v.int32Field)
         , (requiredFieldEncoder "int64Field" numericStringEncoder 0 v.int64Field)
         , (requiredFieldEncoder "uint32Field" JE.int 0 v.uint32Field)
@@ -255,8 +255,8 @@ type alias FooRepeated =
 fooRepeatedDecoder : JD.Decoder FooRepeated
 fooRepeatedDecoder =
     JD.lazy <| \_ -> decode FooRepeated
-        |> repeated "doubleField" JD.float
-        |> repeated "floatField" JD.float
+        |> repeated "doubleField" floatDecoder
+        |> repeated "floatField" floatDecoder
         |> repeated "int32Field" intDecoder
         |> repeated "int64Field" intDecoder
         |> repeated "uint32Field" intDecoder
@@ -276,8 +276,8 @@ fooRepeatedDecoder =
 fooRepeatedEncoder : FooRepeated -> JE.Value
 fooRepeatedEncoder v =
     JE.object <| List.filterMap identity <|
-        [ (repeatedFieldEncoder "doubleField" JE.float v.doubleField)
-        , (repeatedFieldEncoder "floatField" JE.float v.floatField)
+        [ (repeatedFieldEncoder "doubleField" floatEncoder v.doubleField)
+        , (repeatedFieldEncoder "floatField" floatEncoder v.floatField)
         , (repeatedFieldEncoder "int32Field" JE.int v.int32Field)
         , (repeatedFieldEncoder "int64Field" numericStringEncoder v.int64Field)
         , (repeatedFieldEncoder "uint32Field" JE.int v.uint32Field)
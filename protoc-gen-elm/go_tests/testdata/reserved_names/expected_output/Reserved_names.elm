@@ -0,0 +1,146 @@
+module Reserved_names exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: reserved_names.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Dict
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type Answer
+    = False_ -- 0
+    | True_ -- 1
+
+
+answerDecoder : JD.Decoder Answer
+answerDecoder =
+    let
+        lookup s =
+            case s of
+                "FALSE" ->
+                    False_
+
+                "TRUE" ->
+                    True_
+
+                _ ->
+                    False_
+    in
+        JD.map lookup JD.string
+
+
+answerDefault : Answer
+answerDefault = False_
+
+
+answerEncoder : Answer -> JE.Value
+answerEncoder v =
+    let
+        lookup s =
+            case s of
+                False_ ->
+                    "FALSE"
+
+                True_ ->
+                    "TRUE"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Maybe_ =
+    { answer : Answer -- 1
+    }
+
+
+maybe_Decoder : JD.Decoder Maybe_
+maybe_Decoder =
+    JD.lazy <| \_ -> decode Maybe_
+        |> required "answer" answerDecoder answerDefault
+
+
+maybe_Encoder : Maybe_ -> JE.Value
+maybe_Encoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "answer" answerEncoder answerDefault v.answer)
+        ]
+
+
+type alias Dict_ =
+    { entries : Dict.Dict String String -- 1
+    }
+
+
+dict_Decoder : JD.Decoder Dict_
+dict_Decoder =
+    JD.lazy <| \_ -> decode Dict_
+        |> mapEntries "entries" JD.string
+
+
+dict_Encoder : Dict_ -> JE.Value
+dict_Encoder v =
+    JE.object <| List.filterMap identity <|
+        [ (mapEntriesFieldEncoder "entries" JE.string v.entries)
+        ]
+
+
+type alias Dict_EntriesEntry =
+    { key : String -- 1
+    , value : String -- 2
+    }
+
+
+dict_EntriesEntryDecoder : JD.Decoder Dict_EntriesEntry
+dict_EntriesEntryDecoder =
+    JD.lazy <| \_ -> decode Dict_EntriesEntry
+        |> required "key" JD.string ""
+        |> required "value" JD.string ""
+
+
+dict_EntriesEntryEncoder : Dict_EntriesEntry -> JE.Value
+dict_EntriesEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.string "" v.key)
+        , (requiredFieldEncoder "value" JE.string "" v.value)
+        ]
+
+
+type alias Unit_ =
+    { identity : String -- 1
+    , decode : String -- 2
+    , v : String -- 3
+    , type_ : String -- 4
+    , maybe : Maybe Maybe_ -- 5
+    , dict : Maybe Dict_ -- 6
+    }
+
+
+unit_Decoder : JD.Decoder Unit_
+unit_Decoder =
+    JD.lazy <| \_ -> decode Unit_
+        |> required "identity" JD.string ""
+        |> required "decode" JD.string ""
+        |> required "v" JD.string ""
+        |> required "type" JD.string ""
+        |> optional "maybe" maybe_Decoder
+        |> optional "dict" dict_Decoder
+
+
+unit_Encoder : Unit_ -> JE.Value
+unit_Encoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "identity" JE.string "" v.identity)
+        , (requiredFieldEncoder "decode" JE.string "" v.decode)
+        , (requiredFieldEncoder "v" JE.string "" v.v)
+        , (requiredFieldEncoder "type" JE.string "" v.type_)
+        , (optionalEncoder "maybe" maybe_Encoder v.maybe)
+        , (optionalEncoder "dict" dict_Encoder v.dict)
+        ]
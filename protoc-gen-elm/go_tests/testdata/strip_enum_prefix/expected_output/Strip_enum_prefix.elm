@@ -0,0 +1,366 @@
+module Strip_enum_prefix exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: strip_enum_prefix.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type Color
+    = ColorUnspecified -- 0
+    | ColorRed -- 1
+    | ColorGreen -- 2
+
+
+colorDecoder : JD.Decoder Color
+colorDecoder =
+    let
+        lookup s =
+            case s of
+                "COLOR_UNSPECIFIED" ->
+                    ColorUnspecified
+
+                "COLOR_RED" ->
+                    ColorRed
+
+                "COLOR_GREEN" ->
+                    ColorGreen
+
+                _ ->
+                    ColorUnspecified
+    in
+        JD.map lookup JD.string
+
+
+colorDefault : Color
+colorDefault = ColorUnspecified
+
+
+colorEncoder : Color -> JE.Value
+colorEncoder v =
+    let
+        lookup s =
+            case s of
+                ColorUnspecified ->
+                    "COLOR_UNSPECIFIED"
+
+                ColorRed ->
+                    "COLOR_RED"
+
+                ColorGreen ->
+                    "COLOR_GREEN"
+
+    in
+        JE.string <| lookup v
+
+
+type PhoneType
+    = Home -- 0
+    | Work -- 1
+
+
+phoneTypeDecoder : JD.Decoder PhoneType
+phoneTypeDecoder =
+    let
+        lookup s =
+            case s of
+                "PHONE_TYPE_HOME" ->
+                    Home
+
+                "PHONE_TYPE_WORK" ->
+                    Work
+
+                _ ->
+                    Home
+    in
+        JD.map lookup JD.string
+
+
+phoneTypeDefault : PhoneType
+phoneTypeDefault = Home
+
+
+phoneTypeEncoder : PhoneType -> JE.Value
+phoneTypeEncoder v =
+    let
+        lookup s =
+            case s of
+                Home ->
+                    "PHONE_TYPE_HOME"
+
+                Work ->
+                    "PHONE_TYPE_WORK"
+
+    in
+        JE.string <| lookup v
+
+
+type Alert
+    = AlertUnspecified -- 0
+    | AlertRed -- 1
+
+
+alertDecoder : JD.Decoder Alert
+alertDecoder =
+    let
+        lookup s =
+            case s of
+                "ALERT_UNSPECIFIED" ->
+                    AlertUnspecified
+
+                "ALERT_RED" ->
+                    AlertRed
+
+                _ ->
+                    AlertUnspecified
+    in
+        JD.map lookup JD.string
+
+
+alertDefault : Alert
+alertDefault = AlertUnspecified
+
+
+alertEncoder : Alert -> JE.Value
+alertEncoder v =
+    let
+        lookup s =
+            case s of
+                AlertUnspecified ->
+                    "ALERT_UNSPECIFIED"
+
+                AlertRed ->
+                    "ALERT_RED"
+
+    in
+        JE.string <| lookup v
+
+
+type Size
+    = SizeSmall -- 0
+    | Large -- 1
+
+
+sizeDecoder : JD.Decoder Size
+sizeDecoder =
+    let
+        lookup s =
+            case s of
+                "SIZE_SMALL" ->
+                    SizeSmall
+
+                "LARGE" ->
+                    Large
+
+                _ ->
+                    SizeSmall
+    in
+        JD.map lookup JD.string
+
+
+sizeDefault : Size
+sizeDefault = SizeSmall
+
+
+sizeEncoder : Size -> JE.Value
+sizeEncoder v =
+    let
+        lookup s =
+            case s of
+                SizeSmall ->
+                    "SIZE_SMALL"
+
+                Large ->
+                    "LARGE"
+
+    in
+        JE.string <| lookup v
+
+
+type Shape
+    = ShapeUnknown -- 0
+    | Shape2D -- 1
+
+
+shapeDecoder : JD.Decoder Shape
+shapeDecoder =
+    let
+        lookup s =
+            case s of
+                "SHAPE_UNKNOWN" ->
+                    ShapeUnknown
+
+                "SHAPE_2D" ->
+                    Shape2D
+
+                _ ->
+                    ShapeUnknown
+    in
+        JD.map lookup JD.string
+
+
+shapeDefault : Shape
+shapeDefault = ShapeUnknown
+
+
+shapeEncoder : Shape -> JE.Value
+shapeEncoder v =
+    let
+        lookup s =
+            case s of
+                ShapeUnknown ->
+                    "SHAPE_UNKNOWN"
+
+                Shape2D ->
+                    "SHAPE_2D"
+
+    in
+        JE.string <| lookup v
+
+
+type Flag
+    = FlagFalse -- 0
+    | FlagTrue -- 1
+
+
+flagDecoder : JD.Decoder Flag
+flagDecoder =
+    let
+        lookup s =
+            case s of
+                "FLAG_FALSE" ->
+                    FlagFalse
+
+                "FLAG_TRUE" ->
+                    FlagTrue
+
+                _ ->
+                    FlagFalse
+    in
+        JD.map lookup JD.string
+
+
+flagDefault : Flag
+flagDefault = FlagFalse
+
+
+flagEncoder : Flag -> JE.Value
+flagEncoder v =
+    let
+        lookup s =
+            case s of
+                FlagFalse ->
+                    "FLAG_FALSE"
+
+                FlagTrue ->
+                    "FLAG_TRUE"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Outer =
+    { size : Outer_Size -- 1
+    , color : Color -- 2
+    , shape : Outer_Shape -- 3
+    }
+
+
+type Outer_Size
+    = Outer_Small -- 0
+    | Outer_Large -- 1
+
+
+type Outer_Shape
+    = Outer_Circle -- 0
+
+
+outerDecoder : JD.Decoder Outer
+outerDecoder =
+    JD.lazy <| \_ -> decode Outer
+        |> required "size" outer_SizeDecoder outer_SizeDefault
+        |> required "color" colorDecoder colorDefault
+        |> required "shape" outer_ShapeDecoder outer_ShapeDefault
+
+
+outer_SizeDecoder : JD.Decoder Outer_Size
+outer_SizeDecoder =
+    let
+        lookup s =
+            case s of
+                "SIZE_SMALL" ->
+                    Outer_Small
+
+                "SIZE_LARGE" ->
+                    Outer_Large
+
+                _ ->
+                    Outer_Small
+    in
+        JD.map lookup JD.string
+
+
+outer_SizeDefault : Outer_Size
+outer_SizeDefault = Outer_Small
+
+
+outer_ShapeDecoder : JD.Decoder Outer_Shape
+outer_ShapeDecoder =
+    let
+        lookup s =
+            case s of
+                "SHAPE_CIRCLE" ->
+                    Outer_Circle
+
+                _ ->
+                    Outer_Circle
+    in
+        JD.map lookup JD.string
+
+
+outer_ShapeDefault : Outer_Shape
+outer_ShapeDefault = Outer_Circle
+
+
+outerEncoder : Outer -> JE.Value
+outerEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "size" outer_SizeEncoder outer_SizeDefault v.size)
+        , (requiredFieldEncoder "color" colorEncoder colorDefault v.color)
+        , (requiredFieldEncoder "shape" outer_ShapeEncoder outer_ShapeDefault v.shape)
+        ]
+
+
+outer_SizeEncoder : Outer_Size -> JE.Value
+outer_SizeEncoder v =
+    let
+        lookup s =
+            case s of
+                Outer_Small ->
+                    "SIZE_SMALL"
+
+                Outer_Large ->
+                    "SIZE_LARGE"
+
+    in
+        JE.string <| lookup v
+
+
+outer_ShapeEncoder : Outer_Shape -> JE.Value
+outer_ShapeEncoder v =
+    let
+        lookup s =
+            case s of
+                Outer_Circle ->
+                    "SHAPE_CIRCLE"
+
+    in
+        JE.string <| lookup v
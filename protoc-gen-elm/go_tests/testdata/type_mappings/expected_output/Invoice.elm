@@ -0,0 +1,75 @@
+module Invoice exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: invoice.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Dict
+import Our.Rates
+import Our.Money
+import Our.Rate
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Invoice =
+    { id : String -- 1
+    , total : Maybe Our.Money.Money -- 2
+    , lineTotals : List Our.Money.Money -- 3
+    , currency : Our.Money.Currency -- 4
+    , taxes : Dict.Dict String Our.Money.Money -- 5
+    , rate : Maybe Our.Rate.Rate -- 6
+    , rateSource : Maybe Our.Rates.Rate_Source -- 7
+    }
+
+
+invoiceDecoder : JD.Decoder Invoice
+invoiceDecoder =
+    JD.lazy <| \_ -> decode Invoice
+        |> required "id" JD.string ""
+        |> optional "total" Our.Money.moneyDecoder
+        |> repeated "lineTotals" Our.Money.moneyDecoder
+        |> required "currency" Our.Money.currencyDecoder Our.Money.defaultCurrency
+        |> mapEntries "taxes" Our.Money.moneyDecoder
+        |> optional "rate" Our.Rate.rateDecoder
+        |> optional "rateSource" Our.Rates.rate_SourceDecoder
+
+
+invoiceEncoder : Invoice -> JE.Value
+invoiceEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "id" JE.string "" v.id)
+        , (optionalEncoder "total" Our.Money.moneyEncoder v.total)
+        , (repeatedFieldEncoder "lineTotals" Our.Money.moneyEncoder v.lineTotals)
+        , (requiredFieldEncoder "currency" Our.Money.currencyEncoder Our.Money.defaultCurrency v.currency)
+        , (mapEntriesFieldEncoder "taxes" Our.Money.moneyEncoder v.taxes)
+        , (optionalEncoder "rate" Our.Rate.rateEncoder v.rate)
+        , (optionalEncoder "rateSource" Our.Rates.rate_SourceEncoder v.rateSource)
+        ]
+
+
+type alias Invoice_TaxesEntry =
+    { key : String -- 1
+    , value : Maybe Our.Money.Money -- 2
+    }
+
+
+invoice_TaxesEntryDecoder : JD.Decoder Invoice_TaxesEntry
+invoice_TaxesEntryDecoder =
+    JD.lazy <| \_ -> decode Invoice_TaxesEntry
+        |> required "key" JD.string ""
+        |> optional "value" Our.Money.moneyDecoder
+
+
+invoice_TaxesEntryEncoder : Invoice_TaxesEntry -> JE.Value
+invoice_TaxesEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.string "" v.key)
+        , (optionalEncoder "value" Our.Money.moneyEncoder v.value)
+        ]
@@ -0,0 +1,136 @@
+module Unknown_enum_values_fail exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: unknown_enum_values_fail.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type Status
+    = StatusUnspecified -- 0
+    | StatusActive -- 1
+    | StatusEnabled -- 1
+
+
+statusDecoder : JD.Decoder Status
+statusDecoder =
+    let
+        lookup s =
+            case s of
+                "STATUS_UNSPECIFIED" ->
+                    JD.succeed StatusUnspecified
+
+                "STATUS_ACTIVE" ->
+                    JD.succeed StatusActive
+
+                "STATUS_ENABLED" ->
+                    JD.succeed StatusEnabled
+
+                "0" ->
+                    JD.succeed StatusUnspecified
+
+                "1" ->
+                    JD.succeed StatusActive
+
+                _ ->
+                    JD.fail <| "unknown value for enum Status: " ++ s
+    in
+        enumNameOrNumberDecoder |> JD.andThen lookup
+
+
+statusDefault : Status
+statusDefault = StatusUnspecified
+
+
+statusEncoder : Status -> JE.Value
+statusEncoder v =
+    let
+        lookup s =
+            case s of
+                StatusUnspecified ->
+                    "STATUS_UNSPECIFIED"
+
+                StatusActive ->
+                    "STATUS_ACTIVE"
+
+                StatusEnabled ->
+                    "STATUS_ENABLED"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Job =
+    { status : Status -- 1
+    , priority : Job_Priority -- 2
+    }
+
+
+type Job_Priority
+    = Job_Low -- 0
+    | Job_High -- 1
+
+
+jobDecoder : JD.Decoder Job
+jobDecoder =
+    JD.lazy <| \_ -> decode Job
+        |> required "status" statusDecoder statusDefault
+        |> required "priority" job_PriorityDecoder job_PriorityDefault
+
+
+job_PriorityDecoder : JD.Decoder Job_Priority
+job_PriorityDecoder =
+    let
+        lookup s =
+            case s of
+                "LOW" ->
+                    JD.succeed Job_Low
+
+                "HIGH" ->
+                    JD.succeed Job_High
+
+                "0" ->
+                    JD.succeed Job_Low
+
+                "1" ->
+                    JD.succeed Job_High
+
+                _ ->
+                    JD.fail <| "unknown value for enum Priority: " ++ s
+    in
+        enumNameOrNumberDecoder |> JD.andThen lookup
+
+
+job_PriorityDefault : Job_Priority
+job_PriorityDefault = Job_Low
+
+
+jobEncoder : Job -> JE.Value
+jobEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "status" statusEncoder statusDefault v.status)
+        , (requiredFieldEncoder "priority" job_PriorityEncoder job_PriorityDefault v.priority)
+        ]
+
+
+job_PriorityEncoder : Job_Priority -> JE.Value
+job_PriorityEncoder v =
+    let
+        lookup s =
+            case s of
+                Job_Low ->
+                    "LOW"
+
+                Job_High ->
+                    "HIGH"
+
+    in
+        JE.string <| lookup v
@@ -0,0 +1,55 @@
+module Closed exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: closed.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type Closed
+    = ClosedA -- 1
+    | ClosedB -- 2
+
+
+closedDecoder : JD.Decoder Closed
+closedDecoder =
+    let
+        lookup s =
+            case s of
+                "CLOSED_A" ->
+                    JD.succeed ClosedA
+
+                "CLOSED_B" ->
+                    JD.succeed ClosedB
+
+                _ ->
+                    JD.fail <| "unknown value for enum Closed: " ++ s
+    in
+        JD.string |> JD.andThen lookup
+
+
+closedDefault : Closed
+closedDefault = ClosedA
+
+
+closedEncoder : Closed -> JE.Value
+closedEncoder v =
+    let
+        lookup s =
+            case s of
+                ClosedA ->
+                    "CLOSED_A"
+
+                ClosedB ->
+                    "CLOSED_B"
+
+    in
+        JE.string <| lookup v
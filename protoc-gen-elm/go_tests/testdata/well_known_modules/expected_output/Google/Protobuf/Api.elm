@@ -0,0 +1,109 @@
+module Google.Protobuf.Api exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: google/protobuf/api.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Google.Protobuf.Source_context
+import Google.Protobuf.Type
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias Api =
+    { name : String -- 1
+    , methods : List Method -- 2
+    , options : List Google.Protobuf.Type.Option -- 3
+    , version : String -- 4
+    , sourceContext : Maybe Google.Protobuf.Source_context.SourceContext -- 5
+    , mixins : List Mixin -- 6
+    , syntax : Google.Protobuf.Type.Syntax -- 7
+    }
+
+
+apiDecoder : JD.Decoder Api
+apiDecoder =
+    JD.lazy <| \_ -> decode Api
+        |> required "name" JD.string ""
+        |> repeated "methods" methodDecoder
+        |> repeated "options" Google.Protobuf.Type.optionDecoder
+        |> required "version" JD.string ""
+        |> optional "sourceContext" Google.Protobuf.Source_context.sourceContextDecoder
+        |> repeated "mixins" mixinDecoder
+        |> required "syntax" Google.Protobuf.Type.syntaxDecoder Google.Protobuf.Type.syntaxDefault
+
+
+apiEncoder : Api -> JE.Value
+apiEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        , (repeatedFieldEncoder "methods" methodEncoder v.methods)
+        , (repeatedFieldEncoder "options" Google.Protobuf.Type.optionEncoder v.options)
+        , (requiredFieldEncoder "version" JE.string "" v.version)
+        , (optionalEncoder "sourceContext" Google.Protobuf.Source_context.sourceContextEncoder v.sourceContext)
+        , (repeatedFieldEncoder "mixins" mixinEncoder v.mixins)
+        , (requiredFieldEncoder "syntax" Google.Protobuf.Type.syntaxEncoder Google.Protobuf.Type.syntaxDefault v.syntax)
+        ]
+
+
+type alias Method =
+    { name : String -- 1
+    , requestTypeUrl : String -- 2
+    , requestStreaming : Bool -- 3
+    , responseTypeUrl : String -- 4
+    , responseStreaming : Bool -- 5
+    , options : List Google.Protobuf.Type.Option -- 6
+    , syntax : Google.Protobuf.Type.Syntax -- 7
+    }
+
+
+methodDecoder : JD.Decoder Method
+methodDecoder =
+    JD.lazy <| \_ -> decode Method
+        |> required "name" JD.string ""
+        |> required "requestTypeUrl" JD.string ""
+        |> required "requestStreaming" JD.bool False
+        |> required "responseTypeUrl" JD.string ""
+        |> required "responseStreaming" JD.bool False
+        |> repeated "options" Google.Protobuf.Type.optionDecoder
+        |> required "syntax" Google.Protobuf.Type.syntaxDecoder Google.Protobuf.Type.syntaxDefault
+
+
+methodEncoder : Method -> JE.Value
+methodEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        , (requiredFieldEncoder "requestTypeUrl" JE.string "" v.requestTypeUrl)
+        , (requiredFieldEncoder "requestStreaming" JE.bool False v.requestStreaming)
+        , (requiredFieldEncoder "responseTypeUrl" JE.string "" v.responseTypeUrl)
+        , (requiredFieldEncoder "responseStreaming" JE.bool False v.responseStreaming)
+        , (repeatedFieldEncoder "options" Google.Protobuf.Type.optionEncoder v.options)
+        , (requiredFieldEncoder "syntax" Google.Protobuf.Type.syntaxEncoder Google.Protobuf.Type.syntaxDefault v.syntax)
+        ]
+
+
+type alias Mixin =
+    { name : String -- 1
+    , root : String -- 2
+    }
+
+
+mixinDecoder : JD.Decoder Mixin
+mixinDecoder =
+    JD.lazy <| \_ -> decode Mixin
+        |> required "name" JD.string ""
+        |> required "root" JD.string ""
+
+
+mixinEncoder : Mixin -> JE.Value
+mixinEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        , (requiredFieldEncoder "root" JE.string "" v.root)
+        ]
@@ -0,0 +1,32 @@
+module Google.Protobuf.Source_context exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: google/protobuf/source_context.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias SourceContext =
+    { fileName : String -- 1
+    }
+
+
+sourceContextDecoder : JD.Decoder SourceContext
+sourceContextDecoder =
+    JD.lazy <| \_ -> decode SourceContext
+        |> required "fileName" JD.string ""
+
+
+sourceContextEncoder : SourceContext -> JE.Value
+sourceContextEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "fileName" JE.string "" v.fileName)
+        ]
@@ -0,0 +1,437 @@
+module Google.Protobuf.Type exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: google/protobuf/type.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Google.Protobuf.Source_context
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type Syntax
+    = SyntaxProto2 -- 0
+    | SyntaxProto3 -- 1
+    | SyntaxEditions -- 2
+
+
+syntaxDecoder : JD.Decoder Syntax
+syntaxDecoder =
+    let
+        lookup s =
+            case s of
+                "SYNTAX_PROTO2" ->
+                    SyntaxProto2
+
+                "SYNTAX_PROTO3" ->
+                    SyntaxProto3
+
+                "SYNTAX_EDITIONS" ->
+                    SyntaxEditions
+
+                _ ->
+                    SyntaxProto2
+    in
+        JD.map lookup JD.string
+
+
+syntaxDefault : Syntax
+syntaxDefault = SyntaxProto2
+
+
+syntaxEncoder : Syntax -> JE.Value
+syntaxEncoder v =
+    let
+        lookup s =
+            case s of
+                SyntaxProto2 ->
+                    "SYNTAX_PROTO2"
+
+                SyntaxProto3 ->
+                    "SYNTAX_PROTO3"
+
+                SyntaxEditions ->
+                    "SYNTAX_EDITIONS"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Type =
+    { name : String -- 1
+    , fields : List Field -- 2
+    , oneofs : List String -- 3
+    , options : List Option -- 4
+    , sourceContext : Maybe Google.Protobuf.Source_context.SourceContext -- 5
+    , syntax : Syntax -- 6
+    , edition : String -- 7
+    }
+
+
+typeDecoder : JD.Decoder Type
+typeDecoder =
+    JD.lazy <| \_ -> decode Type
+        |> required "name" JD.string ""
+        |> repeated "fields" fieldDecoder
+        |> repeated "oneofs" JD.string
+        |> repeated "options" optionDecoder
+        |> optional "sourceContext" Google.Protobuf.Source_context.sourceContextDecoder
+        |> required "syntax" syntaxDecoder syntaxDefault
+        |> required "edition" JD.string ""
+
+
+typeEncoder : Type -> JE.Value
+typeEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        , (repeatedFieldEncoder "fields" fieldEncoder v.fields)
+        , (repeatedFieldEncoder "oneofs" JE.string v.oneofs)
+        , (repeatedFieldEncoder "options" optionEncoder v.options)
+        , (optionalEncoder "sourceContext" Google.Protobuf.Source_context.sourceContextEncoder v.sourceContext)
+        , (requiredFieldEncoder "syntax" syntaxEncoder syntaxDefault v.syntax)
+        , (requiredFieldEncoder "edition" JE.string "" v.edition)
+        ]
+
+
+type alias Field =
+    { kind : Field_Kind -- 1
+    , cardinality : Field_Cardinality -- 2
+    , number : Int -- 3
+    , name : String -- 4
+    , typeUrl : String -- 6
+    , oneofIndex : Int -- 7
+    , packed : Bool -- 8
+    , options : List Option -- 9
+    , jsonName : String -- 10
+    , defaultValue : String -- 11
+    }
+
+
+type Field_Kind
+    = Field_TypeUnknown -- 0
+    | Field_TypeDouble -- 1
+    | Field_TypeFloat -- 2
+    | Field_TypeInt64 -- 3
+    | Field_TypeUint64 -- 4
+    | Field_TypeInt32 -- 5
+    | Field_TypeFixed64 -- 6
+    | Field_TypeFixed32 -- 7
+    | Field_TypeBool -- 8
+    | Field_TypeString -- 9
+    | Field_TypeGroup -- 10
+    | Field_TypeMessage -- 11
+    | Field_TypeBytes -- 12
+    | Field_TypeUint32 -- 13
+    | Field_TypeEnum -- 14
+    | Field_TypeSfixed32 -- 15
+    | Field_TypeSfixed64 -- 16
+    | Field_TypeSint32 -- 17
+    | Field_TypeSint64 -- 18
+
+
+type Field_Cardinality
+    = Field_CardinalityUnknown -- 0
+    | Field_CardinalityOptional -- 1
+    | Field_CardinalityRequired -- 2
+    | Field_CardinalityRepeated -- 3
+
+
+fieldDecoder : JD.Decoder Field
+fieldDecoder =
+    JD.lazy <| \_ -> decode Field
+        |> required "kind" field_KindDecoder field_KindDefault
+        |> required "cardinality" field_CardinalityDecoder field_CardinalityDefault
+        |> required "number" intDecoder 0
+        |> required "name" JD.string ""
+        |> required "typeUrl" JD.string ""
+        |> required "oneofIndex" intDecoder 0
+        |> required "packed" JD.bool False
+        |> repeated "options" optionDecoder
+        |> required "jsonName" JD.string ""
+        |> required "defaultValue" JD.string ""
+
+
+field_KindDecoder : JD.Decoder Field_Kind
+field_KindDecoder =
+    let
+        lookup s =
+            case s of
+                "TYPE_UNKNOWN" ->
+                    Field_TypeUnknown
+
+                "TYPE_DOUBLE" ->
+                    Field_TypeDouble
+
+                "TYPE_FLOAT" ->
+                    Field_TypeFloat
+
+                "TYPE_INT64" ->
+                    Field_TypeInt64
+
+                "TYPE_UINT64" ->
+                    Field_TypeUint64
+
+                "TYPE_INT32" ->
+                    Field_TypeInt32
+
+                "TYPE_FIXED64" ->
+                    Field_TypeFixed64
+
+                "TYPE_FIXED32" ->
+                    Field_TypeFixed32
+
+                "TYPE_BOOL" ->
+                    Field_TypeBool
+
+                "TYPE_STRING" ->
+                    Field_TypeString
+
+                "TYPE_GROUP" ->
+                    Field_TypeGroup
+
+                "TYPE_MESSAGE" ->
+                    Field_TypeMessage
+
+                "TYPE_BYTES" ->
+                    Field_TypeBytes
+
+                "TYPE_UINT32" ->
+                    Field_TypeUint32
+
+                "TYPE_ENUM" ->
+                    Field_TypeEnum
+
+                "TYPE_SFIXED32" ->
+                    Field_TypeSfixed32
+
+                "TYPE_SFIXED64" ->
+                    Field_TypeSfixed64
+
+                "TYPE_SINT32" ->
+                    Field_TypeSint32
+
+                "TYPE_SINT64" ->
+                    Field_TypeSint64
+
+                _ ->
+                    Field_TypeUnknown
+    in
+        JD.map lookup JD.string
+
+
+field_KindDefault : Field_Kind
+field_KindDefault = Field_TypeUnknown
+
+
+field_CardinalityDecoder : JD.Decoder Field_Cardinality
+field_CardinalityDecoder =
+    let
+        lookup s =
+            case s of
+                "CARDINALITY_UNKNOWN" ->
+                    Field_CardinalityUnknown
+
+                "CARDINALITY_OPTIONAL" ->
+                    Field_CardinalityOptional
+
+                "CARDINALITY_REQUIRED" ->
+                    Field_CardinalityRequired
+
+                "CARDINALITY_REPEATED" ->
+                    Field_CardinalityRepeated
+
+                _ ->
+                    Field_CardinalityUnknown
+    in
+        JD.map lookup JD.string
+
+
+field_CardinalityDefault : Field_Cardinality
+field_CardinalityDefault = Field_CardinalityUnknown
+
+
+fieldEncoder : Field -> JE.Value
+fieldEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "kind" field_KindEncoder field_KindDefault v.kind)
+        , (requiredFieldEncoder "cardinality" field_CardinalityEncoder field_CardinalityDefault v.cardinality)
+        , (requiredFieldEncoder "number" JE.int 0 v.number)
+        , (requiredFieldEncoder "name" JE.string "" v.name)
+        , (requiredFieldEncoder "typeUrl" JE.string "" v.typeUrl)
+        , (requiredFieldEncoder "oneofIndex" JE.int 0 v.oneofIndex)
+        , (requiredFieldEncoder "packed" JE.bool False v.packed)
+        , (repeatedFieldEncoder "options" optionEncoder v.options)
+        , (requiredFieldEncoder "jsonName" JE.string "" v.jsonName)
+        , (requiredFieldEncoder "defaultValue" JE.string "" v.defaultValue)
+        ]
+
+
+field_KindEncoder : Field_Kind -> JE.Value
+field_KindEncoder v =
+    let
+        lookup s =
+            case s of
+                Field_TypeUnknown ->
+                    "TYPE_UNKNOWN"
+
+                Field_TypeDouble ->
+                    "TYPE_DOUBLE"
+
+                Field_TypeFloat ->
+                    "TYPE_FLOAT"
+
+                Field_TypeInt64 ->
+                    "TYPE_INT64"
+
+                Field_TypeUint64 ->
+                    "TYPE_UINT64"
+
+                Field_TypeInt32 ->
+                    "TYPE_INT32"
+
+                Field_TypeFixed64 ->
+                    "TYPE_FIXED64"
+
+                Field_TypeFixed32 ->
+                    "TYPE_FIXED32"
+
+                Field_TypeBool ->
+                    "TYPE_BOOL"
+
+                Field_TypeString ->
+                    "TYPE_STRING"
+
+                Field_TypeGroup ->
+                    "TYPE_GROUP"
+
+                Field_TypeMessage ->
+                    "TYPE_MESSAGE"
+
+                Field_TypeBytes ->
+                    "TYPE_BYTES"
+
+                Field_TypeUint32 ->
+                    "TYPE_UINT32"
+
+                Field_TypeEnum ->
+                    "TYPE_ENUM"
+
+                Field_TypeSfixed32 ->
+                    "TYPE_SFIXED32"
+
+                Field_TypeSfixed64 ->
+                    "TYPE_SFIXED64"
+
+                Field_TypeSint32 ->
+                    "TYPE_SINT32"
+
+                Field_TypeSint64 ->
+                    "TYPE_SINT64"
+
+    in
+        JE.string <| lookup v
+
+
+field_CardinalityEncoder : Field_Cardinality -> JE.Value
+field_CardinalityEncoder v =
+    let
+        lookup s =
+            case s of
+                Field_CardinalityUnknown ->
+                    "CARDINALITY_UNKNOWN"
+
+                Field_CardinalityOptional ->
+                    "CARDINALITY_OPTIONAL"
+
+                Field_CardinalityRequired ->
+                    "CARDINALITY_REQUIRED"
+
+                Field_CardinalityRepeated ->
+                    "CARDINALITY_REPEATED"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Enum =
+    { name : String -- 1
+    , enumvalue : List EnumValue -- 2
+    , options : List Option -- 3
+    , sourceContext : Maybe Google.Protobuf.Source_context.SourceContext -- 4
+    , syntax : Syntax -- 5
+    , edition : String -- 6
+    }
+
+
+enumDecoder : JD.Decoder Enum
+enumDecoder =
+    JD.lazy <| \_ -> decode Enum
+        |> required "name" JD.string ""
+        |> repeated "enumvalue" enumValueDecoder
+        |> repeated "options" optionDecoder
+        |> optional "sourceContext" Google.Protobuf.Source_context.sourceContextDecoder
+        |> required "syntax" syntaxDecoder syntaxDefault
+        |> required "edition" JD.string ""
+
+
+enumEncoder : Enum -> JE.Value
+enumEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        , (repeatedFieldEncoder "enumvalue" enumValueEncoder v.enumvalue)
+        , (repeatedFieldEncoder "options" optionEncoder v.options)
+        , (optionalEncoder "sourceContext" Google.Protobuf.Source_context.sourceContextEncoder v.sourceContext)
+        , (requiredFieldEncoder "syntax" syntaxEncoder syntaxDefault v.syntax)
+        , (requiredFieldEncoder "edition" JE.string "" v.edition)
+        ]
+
+
+type alias EnumValue =
+    { name : String -- 1
+    , number : Int -- 2
+    , options : List Option -- 3
+    }
+
+
+enumValueDecoder : JD.Decoder EnumValue
+enumValueDecoder =
+    JD.lazy <| \_ -> decode EnumValue
+        |> required "name" JD.string ""
+        |> required "number" intDecoder 0
+        |> repeated "options" optionDecoder
+
+
+enumValueEncoder : EnumValue -> JE.Value
+enumValueEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        , (requiredFieldEncoder "number" JE.int 0 v.number)
+        , (repeatedFieldEncoder "options" optionEncoder v.options)
+        ]
+
+
+type alias Option =
+    { name : String -- 1
+    , value : Maybe Any -- 2
+    }
+
+
+optionDecoder : JD.Decoder Option
+optionDecoder =
+    JD.lazy <| \_ -> decode Option
+        |> required "name" JD.string ""
+        |> optional "value" anyDecoder
+
+
+optionEncoder : Option -> JE.Value
+optionEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "name" JE.string "" v.name)
+        , (optionalEncoder "value" anyEncoder v.value)
+        ]
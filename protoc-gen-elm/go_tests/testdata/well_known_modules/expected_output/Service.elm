@@ -0,0 +1,36 @@
+module Service exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: service.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Google.Protobuf.Api
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type alias ServiceDescription =
+    { api : Maybe Google.Protobuf.Api.Api -- 1
+    , updated : Maybe Timestamp -- 2
+    }
+
+
+serviceDescriptionDecoder : JD.Decoder ServiceDescription
+serviceDescriptionDecoder =
+    JD.lazy <| \_ -> decode ServiceDescription
+        |> optional "api" Google.Protobuf.Api.apiDecoder
+        |> optional "updated" timestampDecoder
+
+
+serviceDescriptionEncoder : ServiceDescription -> JE.Value
+serviceDescriptionEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (optionalEncoder "api" Google.Protobuf.Api.apiEncoder v.api)
+        , (optionalEncoder "updated" timestampEncoder v.updated)
+        ]
@@ -9,6 +9,7 @@ import Protobuf exposing (..)
 
 import Json.Decode as JD
 import Json.Encode as JE
+import Dict
 
 
 uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
@@ -16,6 +17,14 @@ uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
 
 type alias Message =
     { doubleValueField : Maybe Float -- 1
+    , durationField : Maybe Duration -- 2
+    , structField : Maybe Struct -- 3
+    , valueField : Maybe Value -- 4
+    , listValueField : Maybe ListValue -- 5
+    , nullValueField : NullValue -- 6
+    , values : List Value -- 7
+    , valueMap : Dict.Dict String Value -- 8
+    , emptyField : Maybe () -- 9
     }
 
 
@@ -23,10 +32,47 @@ messageDecoder : JD.Decoder Message
 messageDecoder =
     JD.lazy <| \_ -> decode Message
         |> optional "doubleValueField" floatValueDecoder
+        |> optional "durationField" durationDecoder
+        |> optional "structField" structDecoder
+        |> optional "valueField" valueDecoder
+        |> optional "listValueField" listValueDecoder
+        |> required "nullValueField" nullValueDecoder ()
+        |> repeated "values" valueDecoder
+        |> mapEntries "valueMap" valueDecoder
+        |> optional "emptyField" unitDecoder
 
 
 messageEncoder : Message -> JE.Value
 messageEncoder v =
     JE.object <| List.filterMap identity <|
         [ (optionalEncoder "doubleValueField" floatValueEncoder v.doubleValueField)
+        , (optionalEncoder "durationField" durationEncoder v.durationField)
+        , (optionalEncoder "structField" structEncoder v.structField)
+        , (optionalEncoder "valueField" valueEncoder v.valueField)
+        , (optionalEncoder "listValueField" listValueEncoder v.listValueField)
+        , (requiredFieldEncoder "nullValueField" nullValueEncoder () v.nullValueField)
+        , (repeatedFieldEncoder "values" valueEncoder v.values)
+        , (mapEntriesFieldEncoder "valueMap" valueEncoder v.valueMap)
+        , (optionalEncoder "emptyField" unitEncoder v.emptyField)
+        ]
+
+
+type alias Message_ValueMapEntry =
+    { key : String -- 1
+    , value : Maybe Value -- 2
+    }
+
+
+message_ValueMapEntryDecoder : JD.Decoder Message_ValueMapEntry
+message_ValueMapEntryDecoder =
+    JD.lazy <| \_ -> decode Message_ValueMapEntry
+        |> required "key" JD.string ""
+        |> optional "value" valueDecoder
+
+
+message_ValueMapEntryEncoder : Message_ValueMapEntry -> JE.Value
+message_ValueMapEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.string "" v.key)
+        , (optionalEncoder "value" valueEncoder v.value)
         ]
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -18,16 +19,26 @@ import (
 )
 
 var (
-	// Maps each type to the file in which it was defined.
-	typeToFile = map[string]string{}
-
 	// Well Known Types.
 	excludedFiles = map[string]bool{
-		"google/protobuf/timestamp.proto": true,
-		"google/protobuf/wrappers.proto":  true,
+		"google/protobuf/any.proto":        true,
+		"google/protobuf/timestamp.proto":  true,
+		"google/protobuf/duration.proto":   true,
+		"google/protobuf/empty.proto":      true,
+		"google/protobuf/field_mask.proto": true,
+		"google/protobuf/struct.proto":     true,
+		"google/protobuf/wrappers.proto":   true,
 	}
 	excludedTypes = map[string]string{
 		".google.protobuf.Timestamp":   "Timestamp",
+		".google.protobuf.Duration":    "Duration",
+		".google.protobuf.Empty":       "()",
+		".google.protobuf.FieldMask":   "FieldMask",
+		".google.protobuf.Any":         "Any",
+		".google.protobuf.Struct":      "Struct",
+		".google.protobuf.Value":       "Value",
+		".google.protobuf.ListValue":   "ListValue",
+		".google.protobuf.NullValue":   "NullValue",
 		".google.protobuf.Int32Value":  "Int",
 		".google.protobuf.Int64Value":  "Int",
 		".google.protobuf.UInt32Value": "Int",
@@ -40,6 +51,14 @@ var (
 	}
 	excludedDecoders = map[string]string{
 		".google.protobuf.Timestamp":   "timestampDecoder",
+		".google.protobuf.Duration":    "durationDecoder",
+		".google.protobuf.Empty":       "unitDecoder",
+		".google.protobuf.FieldMask":   "fieldMaskDecoder",
+		".google.protobuf.Any":         "anyDecoder",
+		".google.protobuf.Struct":      "structDecoder",
+		".google.protobuf.Value":       "valueDecoder",
+		".google.protobuf.ListValue":   "listValueDecoder",
+		".google.protobuf.NullValue":   "nullValueDecoder",
 		".google.protobuf.Int32Value":  "intValueDecoder",
 		".google.protobuf.Int64Value":  "intValueDecoder",
 		".google.protobuf.UInt32Value": "intValueDecoder",
@@ -52,6 +71,14 @@ var (
 	}
 	excludedEncoders = map[string]string{
 		".google.protobuf.Timestamp":   "timestampEncoder",
+		".google.protobuf.Duration":    "durationEncoder",
+		".google.protobuf.Empty":       "unitEncoder",
+		".google.protobuf.FieldMask":   "fieldMaskEncoder",
+		".google.protobuf.Any":         "anyEncoder",
+		".google.protobuf.Struct":      "structEncoder",
+		".google.protobuf.Value":       "valueEncoder",
+		".google.protobuf.ListValue":   "listValueEncoder",
+		".google.protobuf.NullValue":   "nullValueEncoder",
 		".google.protobuf.Int32Value":  "intValueEncoder",
 		".google.protobuf.Int64Value":  "numericStringEncoder",
 		".google.protobuf.UInt32Value": "intValueEncoder",
@@ -62,6 +89,35 @@ var (
 		".google.protobuf.BytesValue":  "bytesValueEncoder",
 		".google.protobuf.BoolValue":   "boolValueEncoder",
 	}
+	// Default values of the Well Known Types that are enums.
+	excludedDefaults = map[string]string{
+		".google.protobuf.NullValue": "()",
+	}
+
+	// Well Known Types whose representation depends on the options.
+	elmBytesWellKnownTypes = map[string]wellKnownType{
+		".google.protobuf.BytesValue": {"Bytes.Bytes", "elmBytesValueDecoder", "elmBytesValueEncoder"},
+	}
+	stringInt64WellKnownTypes = map[string]wellKnownType{
+		".google.protobuf.Int64Value":  {signedInt64.elmType, signedInt64.decoder, signedInt64.encoder},
+		".google.protobuf.UInt64Value": {unsignedInt64.elmType, unsignedInt64.decoder, unsignedInt64.encoder},
+	}
+
+	// Representations of 64-bit integers when the `int64=string` option is set.
+	signedInt64 = int64Representation{
+		elmType:    "Int64",
+		decoder:    "int64Decoder",
+		encoder:    "int64Encoder",
+		zero:       "int64Zero",
+		fromString: "int64FromString",
+	}
+	unsignedInt64 = int64Representation{
+		elmType:    "UInt64",
+		decoder:    "uint64Decoder",
+		encoder:    "uint64Encoder",
+		zero:       "uint64Zero",
+		fromString: "uint64FromString",
+	}
 
 	// Avoid collisions with reserved keywords by appending a single underscore after the name.
 	// This does not guarantee that collisions are avoided, but makes them less likely to
@@ -82,8 +138,158 @@ var (
 		"port":     true,
 		"as":       true,
 	}
+
+	// reservedTypeNames are the types and constructors exposed by the Elm core modules imported by
+	// default and by the runtime library, which generated types and constructors must not shadow.
+	reservedTypeNames = map[string]bool{
+		// Elm core.
+		"Array":   true,
+		"Bool":    true,
+		"Char":    true,
+		"Cmd":     true,
+		"Dict":    true,
+		"EQ":      true,
+		"Err":     true,
+		"False":   true,
+		"Float":   true,
+		"GT":      true,
+		"Int":     true,
+		"Just":    true,
+		"LT":      true,
+		"List":    true,
+		"Maybe":   true,
+		"Never":   true,
+		"Nothing": true,
+		"Ok":      true,
+		"Order":   true,
+		"Program": true,
+		"Result":  true,
+		"Set":     true,
+		"String":  true,
+		"Sub":     true,
+		"True":    true,
+		// Runtime library.
+		"Any":         true,
+		"BoolDict":    true,
+		"BoolValue":   true,
+		"Bytes":       true,
+		"Duration":    true,
+		"FieldMask":   true,
+		"Int64":       true,
+		"ListValue":   true,
+		"NullValue":   true,
+		"NumberValue": true,
+		"StringValue": true,
+		"Struct":      true,
+		"StructValue": true,
+		"Timestamp":   true,
+		"UInt64":      true,
+		"Value":       true,
+	}
+
+	// runtimeValues are the values exposed by the runtime library, which the decoders, encoders and
+	// other values generated for a type must not shadow.
+	runtimeValues = map[string]bool{
+		"decode":                       true,
+		"required":                     true,
+		"requiredStrict":               true,
+		"optional":                     true,
+		"repeated":                     true,
+		"field":                        true,
+		"withDefault":                  true,
+		"intDecoder":                   true,
+		"floatDecoder":                 true,
+		"fromResult":                   true,
+		"requiredFieldEncoder":         true,
+		"requiredStrictFieldEncoder":   true,
+		"optionalEncoder":              true,
+		"repeatedFieldEncoder":         true,
+		"numericStringEncoder":         true,
+		"floatEncoder":                 true,
+		"mapEntries":                   true,
+		"mapEntriesFieldEncoder":       true,
+		"keyedMapEntries":              true,
+		"keyedMapEntriesFieldEncoder":  true,
+		"emptyBoolDict":                true,
+		"boolMapEntries":               true,
+		"boolMapEntriesFieldEncoder":   true,
+		"enumNameOrNumberDecoder":      true,
+		"enumNameOrNumberEncoder":      true,
+		"int64Decoder":                 true,
+		"int64Encoder":                 true,
+		"int64Zero":                    true,
+		"int64FromString":              true,
+		"int64ToString":                true,
+		"int64FromInt":                 true,
+		"uint64Decoder":                true,
+		"uint64Encoder":                true,
+		"uint64Zero":                   true,
+		"uint64FromString":             true,
+		"uint64ToString":               true,
+		"uint64FromInt":                true,
+		"bytesFieldDecoder":            true,
+		"bytesFieldEncoder":            true,
+		"elmBytesFieldDecoder":         true,
+		"elmBytesFieldEncoder":         true,
+		"requiredElmBytesFieldEncoder": true,
+		"emptyElmBytes":                true,
+		"elmBytesFromList":             true,
+		"elmBytesToList":               true,
+		"timestampDecoder":             true,
+		"timestampEncoder":             true,
+		"durationDecoder":              true,
+		"durationEncoder":              true,
+		"fieldMaskDecoder":             true,
+		"fieldMaskEncoder":             true,
+		"unitDecoder":                  true,
+		"unitEncoder":                  true,
+		"anyDecoder":                   true,
+		"anyEncoder":                   true,
+		"anyTypeName":                  true,
+		"anyUnpack":                    true,
+		"anyPack":                      true,
+		"anyUnpackWellKnown":           true,
+		"anyPackWellKnown":             true,
+		"valueDecoder":                 true,
+		"valueEncoder":                 true,
+		"structDecoder":                true,
+		"structEncoder":                true,
+		"listValueDecoder":             true,
+		"listValueEncoder":             true,
+		"nullValueDecoder":             true,
+		"nullValueEncoder":             true,
+		"intValueDecoder":              true,
+		"intValueEncoder":              true,
+		"stringValueDecoder":           true,
+		"stringValueEncoder":           true,
+		"boolValueDecoder":             true,
+		"boolValueEncoder":             true,
+		"bytesValueDecoder":            true,
+		"bytesValueEncoder":            true,
+		"elmBytesValueDecoder":         true,
+		"elmBytesValueEncoder":         true,
+		"floatValueDecoder":            true,
+		"floatValueEncoder":            true,
+	}
 )
 
+// wellKnownType describes how a Well Known Type is represented in Elm, using the types and
+// functions provided by the runtime library.
+type wellKnownType struct {
+	elmType string
+	decoder string
+	encoder string
+}
+
+// int64Representation describes the runtime library type used for 64-bit integers.
+type int64Representation struct {
+	elmType    string
+	decoder    string
+	encoder    string
+	zero       string
+	fromString string
+}
+
 func main() {
 	data, err := ioutil.ReadAll(os.Stdin)
 	if err != nil {
@@ -97,121 +303,220 @@ func main() {
 		log.Fatalf("Could not unmarshal request: %v", err)
 	}
 
+	resp := generate(req)
+
+	data, err = proto.Marshal(resp)
+	if err != nil {
+		log.Fatalf("Could not marshal response: %v [%v]", err, resp)
+	}
+
+	_, err = os.Stdout.Write(data)
+	if err != nil {
+		log.Fatalf("Could not write response to STDOUT: %v", err)
+	}
+}
+
+// generate processes the request and returns the response to send back to protoc. Any problems
+// with the input are reported through the Error field of the response, so that protoc can show
+// them to the user.
+func generate(req *plugin.CodeGeneratorRequest) *plugin.CodeGeneratorResponse {
+	options, err := parseOptions(req.GetParameter())
+	if err != nil {
+		return &plugin.CodeGeneratorResponse{
+			Error: proto.String(fmt.Sprintf("invalid plugin parameter: %v", err)),
+		}
+	}
+
 	// Remove useless source code data.
 	for _, inFile := range req.GetProtoFile() {
 		inFile.SourceCodeInfo = nil
 	}
 
-	log.Printf("Input data: %v", proto.MarshalTextString(req))
+	if options.Debug {
+		log.Printf("Input data: %v", proto.MarshalTextString(req))
+	}
 
-	resp := &plugin.CodeGeneratorResponse{}
+	resp := &plugin.CodeGeneratorResponse{
+		SupportedFeatures: proto.Uint64(uint64(
+			plugin.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL |
+				plugin.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)),
+		MinimumEdition: proto.Int32(int32(minimumEdition)),
+		MaximumEdition: proto.Int32(int32(maximumEdition)),
+	}
 
+	// Index all the files in the request, including dependencies, so that types defined in them
+	// can be resolved, but only generate code for the files that were explicitly requested.
+	filesToGenerate := map[string]bool{}
+	for _, f := range req.GetFileToGenerate() {
+		filesToGenerate[f] = true
+	}
+
+	types := newTypeIndex(req.GetProtoFile())
+
+	// Keep going after a file fails, so that all the problems are reported at once.
+	var errs errorList
+
+	// Requested files are generated, as well as the Well Known Types with the
+	// `well_known_modules` option.
+	isGenerated := func(inFile *descriptor.FileDescriptorProto) bool {
+		return filesToGenerate[inFile.GetName()] || (options.WellKnownModules && isWellKnownFile(inFile))
+	}
+
+	// Names are assigned for all the files, since the generated files may refer to types defined
+	// in the others, but collisions are only reported for the generated files.
 	for _, inFile := range req.GetProtoFile() {
-		log.Printf("Processing file %s", inFile.GetName())
+		err := types.names.addFile(inFile, options)
+		if err != nil && isGenerated(inFile) && !excludedFiles[inFile.GetName()] {
+			errs = append(errs, err)
+		}
+	}
+	var generated []*descriptor.FileDescriptorProto
+	// Maps each generated Elm module name to the file it was generated from.
+	moduleFiles := map[string]string{}
+	for _, inFile := range req.GetProtoFile() {
+		if !isGenerated(inFile) {
+			continue
+		}
+		if options.Debug {
+			log.Printf("Processing file %s", inFile.GetName())
+		}
 		// Well Known Types.
 		if excludedFiles[inFile.GetName()] {
-			log.Printf("Skipping well known type")
+			if options.Debug {
+				log.Printf("Skipping well known type")
+			}
+			continue
+		}
+		moduleName := elmModuleName(inFile, options)
+		if other, ok := moduleFiles[moduleName]; ok {
+			errs = append(errs, fileErrorf(inFile, "Elm module %s is also generated for %s; use the elm_module option to rename one of them", moduleName, other))
 			continue
 		}
-		outFile, err := processFile(inFile)
+		moduleFiles[moduleName] = inFile.GetName()
+		outFile, err := processFile(inFile, types, options)
 		if err != nil {
-			log.Fatalf("Could not process file: %v", err)
+			errs = append(errs, err)
+			continue
 		}
 		resp.File = append(resp.File, outFile)
+		generated = append(generated, inFile)
 	}
 
-	data, err = proto.Marshal(resp)
-	if err != nil {
-		log.Fatalf("Could not marshal response: %v [%v]", err, resp)
+	if other, ok := moduleFiles[options.AnyRegistry]; ok {
+		errs = append(errs, fmt.Errorf("%s: Elm module %s is also generated for the any_registry option; use the elm_module or any_registry option to rename one of them", other, options.AnyRegistry))
 	}
 
-	_, err = os.Stdout.Write(data)
-	if err != nil {
-		log.Fatalf("Could not write response to STDOUT: %v", err)
+	if options.AnyRegistry != "" {
+		outFile, err := generateAnyRegistry(generated, types, options)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			resp.File = append(resp.File, outFile)
+		}
 	}
-}
 
-func hasMapEntries(inFile *descriptor.FileDescriptorProto) bool {
-	for _, m := range inFile.GetMessageType() {
-		if hasMapEntriesInMessage(m) {
-			return true
+	if len(errs) > 0 {
+		return &plugin.CodeGeneratorResponse{
+			Error: proto.String(errs.Error()),
 		}
 	}
 
-	return false
+	return resp
 }
 
-func hasMapEntriesInMessage(inMessage *descriptor.DescriptorProto) bool {
-	if inMessage.GetOptions().GetMapEntry() {
-		return true
+// importedFiles returns the names of the files whose generated modules are referenced by the
+// generated code: the dependencies of the file, followed by the files defining types that are only
+// reachable through a public import. Well Known Types and mapped types are not included, since
+// they are provided by other modules.
+func (fg *FileGenerator) importedFiles(inFile *descriptor.FileDescriptorProto) []string {
+	out := []string{}
+	seen := map[string]bool{}
+	add := func(d string) {
+		if seen[d] || d == inFile.GetName() || excludedFiles[d] || fg.isMappedFile(d) {
+			return
+		}
+		seen[d] = true
+		out = append(out, d)
 	}
 
-	for _, m := range inMessage.GetNestedType() {
-		if hasMapEntriesInMessage(m) {
-			return true
+	for _, d := range inFile.GetDependency() {
+		add(d)
+	}
+
+	indirect := []string{}
+	anyField(inFile, func(inField *descriptor.FieldDescriptorProto) bool {
+		if t, ok := fg.types.byName[inField.GetTypeName()]; ok && !seen[t.file.GetName()] {
+			if _, ok := fg.wellKnownType(inField.GetTypeName()); !ok {
+				indirect = append(indirect, t.file.GetName())
+			}
 		}
+		return false
+	})
+	sort.Strings(indirect)
+	for _, d := range indirect {
+		add(d)
 	}
 
-	return false
+	return out
 }
 
-func processFile(inFile *descriptor.FileDescriptorProto) (*plugin.CodeGeneratorResponse_File, error) {
-	if inFile.GetSyntax() != "proto3" {
-		return nil, fmt.Errorf("Only proto3 syntax is supported")
-	}
-
-	outFile := &plugin.CodeGeneratorResponse_File{}
+// isWellKnownFile returns whether the file is one of the google/protobuf/*.proto files distributed
+// with protoc.
+func isWellKnownFile(inFile *descriptor.FileDescriptorProto) bool {
+	return strings.HasPrefix(inFile.GetName(), "google/protobuf/") && inFile.GetPackage() == "google.protobuf"
+}
 
-	inFilePath := inFile.GetName()
-	inFileDir, inFileName := filepath.Split(inFilePath)
+// hasMapFields returns whether any of the messages in the file has a map field.
+func (fg *FileGenerator) hasMapFields(inFile *descriptor.FileDescriptorProto) bool {
+	return anyField(inFile, func(inField *descriptor.FieldDescriptorProto) bool {
+		isMap, _, _ := fg.mapEntries(inField)
+		return isMap
+	})
+}
 
-	shortModuleName := firstUpper(strings.TrimSuffix(inFileName, ".proto"))
+// usesBytes returns whether any of the messages in the file has a bytes (or BytesValue) field.
+func usesBytes(inFile *descriptor.FileDescriptorProto) bool {
+	return anyField(inFile, func(inField *descriptor.FieldDescriptorProto) bool {
+		return inField.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES ||
+			inField.GetTypeName() == ".google.protobuf.BytesValue"
+	})
+}
 
-	fullModuleName := ""
-	outFileName := ""
-	for _, segment := range strings.Split(inFileDir, "/") {
-		if segment == "" {
-			continue
-		}
-		fullModuleName += firstUpper(segment) + "."
-		outFileName += firstUpper(segment) + "/"
+func processFile(inFile *descriptor.FileDescriptorProto, types typeIndex, options *Options) (*plugin.CodeGeneratorResponse_File, error) {
+	errs := validateFile(inFile, options)
+	if len(errs) > 0 {
+		return nil, errs
 	}
-	fullModuleName += shortModuleName
-	outFileName += shortModuleName + ".elm"
 
-	outFile.Name = proto.String(outFileName)
+	outFile := &plugin.CodeGeneratorResponse_File{}
+	outFile.Name = proto.String(elmFileName(inFile, options))
 
 	b := &bytes.Buffer{}
-	fg := NewFileGenerator(b, inFileName)
+	fg := NewFileGenerator(b, inFile.GetName(), types, resolveFeatures(inFile), options)
 
-	fg.GenerateModule(fullModuleName)
+	fg.GenerateModule(elmModuleName(inFile, options))
 	fg.GenerateComments(inFile)
 
 	fg.GenerateBaseImports()
 
 	// only `import Dict` if it's going to be used, in case
 	// any linters are watching
-	includeDictImport := hasMapEntries(inFile)
+	includeDictImport := fg.hasMapFields(inFile)
 	if includeDictImport {
 		fg.P("import Dict")
 	}
 
+	if options.Bytes == bytesElmBytes && usesBytes(inFile) {
+		fg.P("import Bytes")
+	}
+
 	// Generate additional imports.
-	for _, d := range inFile.GetDependency() {
-		// Well Known Types.
-		if excludedFiles[d] {
-			continue
-		}
-		fullModuleName := ""
-		for _, segment := range strings.Split(strings.TrimSuffix(d, ".proto"), "/") {
-			if segment == "" {
-				continue
-			}
-			fullModuleName += firstUpper(segment) + "."
-		}
-		fullModuleName = strings.TrimSuffix(fullModuleName, ".")
-		// TODO: Do not expose everything.
-		fg.P("import %s exposing (..)", fullModuleName)
+	for _, d := range fg.importedFiles(inFile) {
+		fg.P("import %s", elmModuleName(types.files[d], options))
+	}
+
+	for _, m := range fg.typeMappingModules(inFile) {
+		fg.P("import %s", m)
 	}
 
 	fg.P("")
@@ -222,31 +527,27 @@ func processFile(inFile *descriptor.FileDescriptorProto) (*plugin.CodeGeneratorR
 
 	// Top-level enums.
 	for _, inEnum := range inFile.GetEnumType() {
-		typeToFile[strings.TrimPrefix(inFile.GetPackage()+"."+inEnum.GetName(), ".")] = inFile.GetName()
-
-		err = fg.GenerateEnumDefinition("", inEnum)
+		err = fg.GenerateEnumDefinition(inEnum)
 		if err != nil {
-			return nil, err
+			return nil, fileErrorf(inFile, "enum %s: %v", inEnum.GetName(), err)
 		}
 
-		err = fg.GenerateEnumDecoder("", inEnum)
+		err = fg.GenerateEnumDecoder(inEnum)
 		if err != nil {
-			return nil, err
+			return nil, fileErrorf(inFile, "enum %s: %v", inEnum.GetName(), err)
 		}
 
-		err = fg.GenerateEnumEncoder("", inEnum)
+		err = fg.GenerateEnumEncoder(inEnum)
 		if err != nil {
-			return nil, err
+			return nil, fileErrorf(inFile, "enum %s: %v", inEnum.GetName(), err)
 		}
 	}
 
 	// Top-level messages.
 	for _, inMessage := range inFile.GetMessageType() {
-		typeToFile[strings.TrimPrefix(inFile.GetPackage()+"."+inMessage.GetName(), ".")] = inFile.GetName()
-
-		err = fg.GenerateEverything("", inMessage)
+		err = fg.GenerateEverything(inMessage)
 		if err != nil {
-			return nil, err
+			return nil, fileErrorf(inFile, "message %s: %v", inMessage.GetName(), err)
 		}
 	}
 
@@ -255,6 +556,83 @@ func processFile(inFile *descriptor.FileDescriptorProto) (*plugin.CodeGeneratorR
 	return outFile, nil
 }
 
+// elmModuleName returns the name of the Elm module generated for the given proto file, e.g.
+// `Foo.Bar` for `foo/bar.proto`, or `Foo.V1.Bar` if its package is `foo.v1` and the
+// `module_naming=package` option is set. The `elm_module` option takes precedence over both.
+func elmModuleName(inFile *descriptor.FileDescriptorProto, options *Options) string {
+	if moduleName, ok := options.ModuleOverrides[inFile.GetName()]; ok {
+		return moduleName
+	}
+	pathSegments := strings.Split(strings.TrimSuffix(inFile.GetName(), ".proto"), "/")
+	if options.ModuleNaming == moduleNamingPackage && inFile.GetPackage() != "" {
+		pathSegments = append(strings.Split(inFile.GetPackage(), "."), pathSegments[len(pathSegments)-1])
+	}
+	segments := []string{}
+	for _, segment := range pathSegments {
+		if segment == "" {
+			continue
+		}
+		segments = append(segments, firstUpper(segment))
+	}
+	return strings.Join(segments, ".")
+}
+
+// elmFileName returns the path of the Elm file generated for the given proto file, relative to the
+// output directory.
+func elmFileName(inFile *descriptor.FileDescriptorProto, options *Options) string {
+	_, overridden := options.ModuleOverrides[inFile.GetName()]
+	if overridden || options.ModuleNaming != moduleNamingPath {
+		return strings.Replace(elmModuleName(inFile, options), ".", "/", -1) + ".elm"
+	}
+
+	inFileDir, inFileName := filepath.Split(inFile.GetName())
+	outFileName := ""
+	for _, segment := range strings.Split(inFileDir, "/") {
+		if segment == "" {
+			continue
+		}
+		outFileName += firstUpper(segment) + "/"
+	}
+	return outFileName + firstUpper(strings.TrimSuffix(inFileName, ".proto")) + ".elm"
+}
+
+// fileSyntax returns the syntax of the file, treating a missing declaration as proto2. Files using
+// editions have syntax "editions".
+func fileSyntax(inFile *descriptor.FileDescriptorProto) string {
+	if inFile.GetSyntax() == "" {
+		return "proto2"
+	}
+	return inFile.GetSyntax()
+}
+
+// wellKnownType returns the Elm representation of the given Well Known Type or type mapped by the
+// `type_mappings` option, if it is one, taking the options into account.
+func (fg *FileGenerator) wellKnownType(typeName string) (wellKnownType, bool) {
+	if t, ok := fg.typeMapping(typeName); ok {
+		return t, true
+	}
+	if fg.options.Bytes == bytesElmBytes {
+		if t, ok := elmBytesWellKnownTypes[typeName]; ok {
+			return t, true
+		}
+	}
+	if fg.options.Int64 == int64String {
+		if t, ok := stringInt64WellKnownTypes[typeName]; ok {
+			return t, true
+		}
+	}
+
+	elmType, ok := excludedTypes[typeName]
+	if !ok {
+		return wellKnownType{}, false
+	}
+	return wellKnownType{
+		elmType: elmType,
+		decoder: excludedDecoders[typeName],
+		encoder: excludedEncoders[typeName],
+	}, true
+}
+
 func (fg *FileGenerator) GenerateModule(moduleName string) {
 	fg.P("module %s exposing (..)", moduleName)
 }
@@ -275,60 +653,44 @@ func (fg *FileGenerator) GenerateBaseImports() {
 	fg.P("import Json.Encode as JE")
 }
 
-func (fg *FileGenerator) GenerateEverything(prefix string, inMessage *descriptor.DescriptorProto) error {
-	newPrefix := prefix + inMessage.GetName() + "_"
+func (fg *FileGenerator) GenerateEverything(inMessage *descriptor.DescriptorProto) error {
 	var err error
 
-	if inMessage.Options.GetMapEntry() {
-		if len(inMessage.Field) != 2 {
-			return fmt.Errorf("map entry must have exactly two fields")
-		}
-
-		keyField := inMessage.Field[0]
-		if keyField.GetName() != "key" {
-			return fmt.Errorf("first map entry field must be called `key`")
-		}
-		if keyField.GetType() != descriptor.FieldDescriptorProto_TYPE_STRING {
-			return fmt.Errorf("map key must have type `string`")
-		}
-
-		valueField := inMessage.Field[1]
-		if valueField.GetName() != "value" {
-			return fmt.Errorf("second map entry field must be called `value`")
-		}
-	}
-
-	err = fg.GenerateMessageDefinition(prefix, inMessage)
+	err = fg.GenerateMessageDefinition(inMessage)
 	if err != nil {
 		return err
 	}
 
 	for _, inEnum := range inMessage.GetEnumType() {
-		err = fg.GenerateEnumDefinition(newPrefix, inEnum)
+		err = fg.GenerateEnumDefinition(inEnum)
 		if err != nil {
 			return err
 		}
 	}
 
-	err = fg.GenerateMessageDecoder(prefix, inMessage)
+	err = fg.GenerateMessageDecoder(inMessage)
 	if err != nil {
 		return err
 	}
 
 	for _, inEnum := range inMessage.GetEnumType() {
-		err = fg.GenerateEnumDecoder(newPrefix, inEnum)
+		err = fg.GenerateEnumDecoder(inEnum)
 		if err != nil {
 			return err
 		}
 	}
 
-	err = fg.GenerateMessageEncoder(prefix, inMessage)
+	err = fg.GenerateMessageEncoder(inMessage)
 	if err != nil {
 		return err
 	}
 
+	if fg.options.FieldMaskPaths && !inMessage.GetOptions().GetMapEntry() {
+		fg.GenerateFieldMaskPaths(inMessage)
+	}
+
 	for _, inEnum := range inMessage.GetEnumType() {
-		err = fg.GenerateEnumEncoder(newPrefix, inEnum)
+		err = fg.GenerateEnumEncoder(inEnum)
 		if err != nil {
 			return err
 		}
@@ -336,7 +698,7 @@ func (fg *FileGenerator) GenerateEverything(prefix string, inMessage *descriptor
 
 	// Nested messages.
 	for _, nested := range inMessage.GetNestedType() {
-		err = fg.GenerateEverything(newPrefix, nested)
+		err = fg.GenerateEverything(nested)
 		if err != nil {
 			return err
 		}
@@ -353,6 +715,31 @@ func elmTypeName(in string) string {
 	return n
 }
 
+// avoidReservedTypeName appends an underscore to a type or constructor name that is reserved, or
+// whose decoder, encoder or other derived values would shadow those of the runtime library, e.g.
+// `Maybe_` for a message `Maybe` or `Unit_` for a message `Unit` (because of `unitDecoder`).
+//
+// Record fields are in a separate namespace, so only keywords are reserved for them. Generated
+// functions use local variables (e.g. `v` and `lookup`), but all the generated top-level values
+// have a suffix, so they cannot be shadowed.
+func avoidReservedTypeName(n string) string {
+	if reservedTypeNames[n] || isReservedValueName(firstLower(n)) {
+		return n + "_"
+	}
+	return n
+}
+
+// isReservedValueName returns whether any of the values generated for a type, whose name is given
+// with the first letter in lower case, would shadow a value of the runtime library.
+func isReservedValueName(lowerName string) bool {
+	for _, suffix := range []string{"Decoder", "Encoder", "Default", "Paths"} {
+		if runtimeValues[lowerName+suffix] {
+			return true
+		}
+	}
+	return false
+}
+
 func elmFieldName(in string) string {
 	n := firstLower(camelCase(in))
 	if reservedKeywords[n] {
@@ -377,20 +764,15 @@ func decoderName(typeName string) string {
 	return firstLower(typeName) + "Decoder"
 }
 
-func elmFieldType(field *descriptor.FieldDescriptorProto) string {
-	inFieldName := field.GetTypeName()
-	packageName, messageName := convert(inFieldName)
-
-	// Since we are exposing everything from imported modules, we do not use the package name at
-	// all here.
-	// TODO: Change this.
-	packageName = ""
-
-	if packageName == "" {
-		return messageName
-	} else {
-		return packageName + "." + messageName
+// qualified returns the given name of an Elm type or value generated for a proto type, qualified
+// by the module that defines it if the type is defined in a different file than the one being
+// generated.
+func (fg *FileGenerator) qualified(typeName, name string) string {
+	t, ok := fg.types.byName[typeName]
+	if !ok || t.file.GetName() == fg.inFileName {
+		return name
 	}
+	return elmModuleName(t.file, fg.options) + "." + name
 }
 
 // Returns package name and message name.
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 )
@@ -11,18 +13,21 @@ import (
 // In order for `map<,>` fields to be supported by proto2 format, they
 // get parsed as a backwards compatible form:
 //
-//     map<KeyType, ValueType> map_field = 1;
+//	map<KeyType, ValueType> map_field = 1;
+//
 // Gets parsed as:
-//     message MapFieldEntry {
-//         option map_entry = true;
-//         optional KeyType key = 1;
-//         optional ValueType value = 2;
-//     }
-//     repeated MapFieldEntry map_field = 1;
 //
-// our code looks for the `map_entry` option to detect `map<,>` fields, and generate Dict's for them
+//	message MapFieldEntry {
+//	    option map_entry = true;
+//	    optional KeyType key = 1;
+//	    optional ValueType value = 2;
+//	}
+//	repeated MapFieldEntry map_field = 1;
+//
+// our code looks for the `map_entry` option on the referenced type, resolved by its fully-qualified
+// name, to detect `map<,>` fields, and generate Dict's for them
 // https://github.com/golang/protobuf/blob/882cf97a83ad205fd22af574246a3bc647d7a7d2/protoc-gen-go/descriptor/descriptor.proto#L474-L495
-func mapEntries(inField *descriptor.FieldDescriptorProto, inMessage *descriptor.DescriptorProto) (isMap bool, keyFieldDescriptor *descriptor.FieldDescriptorProto, valueFieldDescriptor *descriptor.FieldDescriptorProto) {
+func (fg *FileGenerator) mapEntries(inField *descriptor.FieldDescriptorProto) (isMap bool, keyFieldDescriptor *descriptor.FieldDescriptorProto, valueFieldDescriptor *descriptor.FieldDescriptorProto) {
 	isRepeated :=
 		inField.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED &&
 			inField.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE
@@ -31,20 +36,81 @@ func mapEntries(inField *descriptor.FieldDescriptorProto, inMessage *descriptor.
 		return false, nil, nil
 	}
 
-	fullyQualifiedTypeName := inField.GetTypeName()
-	splitName := strings.Split(fullyQualifiedTypeName, ".")
-	localTypeName := splitName[len(splitName)-1]
+	entry := fg.types.message(inField)
+	if entry == nil || !entry.GetOptions().GetMapEntry() || len(entry.GetField()) != 2 {
+		return false, nil, nil
+	}
+	return true, entry.GetField()[0], entry.GetField()[1]
+}
 
-	for _, nested := range inMessage.GetNestedType() {
-		if nested.GetName() == localTypeName && nested.GetOptions().GetMapEntry() {
-			return true, nested.GetField()[0], nested.GetField()[1]
-		}
+// mapKey describes how the keys of a map field are represented in Elm.
+//
+// Proto3 JSON always encodes map keys as strings, so keys of other types are converted with the
+// fromString and toString functions when decoding and encoding.
+type mapKey struct {
+	// Elm type of the Dict keys.
+	elmType string
+	// Elm functions converting keys from and to their JSON form, empty for string keys.
+	fromString string
+	toString   string
+	// Bool is not comparable in Elm, so bool keys use the runtime's BoolDict instead of a Dict.
+	isBool bool
+}
+
+func (fg *FileGenerator) mapKey(keyField *descriptor.FieldDescriptorProto) mapKey {
+	switch keyField.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return mapKey{elmType: "String"}
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return mapKey{isBool: true}
+	}
+
+	if _, ok := fg.int64Type(keyField); ok {
+		// Int64 and UInt64 are not comparable, so their decimal representation is used as key.
+		return mapKey{elmType: "String"}
+	}
+	return mapKey{elmType: "Int", fromString: "String.toInt", toString: "String.fromInt"}
+}
+
+// mapFieldType returns the Elm type of a map field.
+func (fg *FileGenerator) mapFieldType(keyField, valueField *descriptor.FieldDescriptorProto) string {
+	key := fg.mapKey(keyField)
+	if key.isBool {
+		return "BoolDict " + fg.fieldElmType(valueField)
+	}
+	return fmt.Sprintf("Dict.Dict %s %s", key.elmType, fg.fieldElmType(valueField))
+}
+
+// mapFieldDecoder returns the decoder pipeline step for a map field.
+func (fg *FileGenerator) mapFieldDecoder(inField, keyField, valueField *descriptor.FieldDescriptorProto) string {
+	key := fg.mapKey(keyField)
+	d := fg.fieldDecoderName(valueField)
+	switch {
+	case key.isBool:
+		return fmt.Sprintf("boolMapEntries %q %s", jsonFieldName(inField), d)
+	case key.fromString != "":
+		return fmt.Sprintf("keyedMapEntries %q %s %s", jsonFieldName(inField), key.fromString, d)
+	default:
+		return fmt.Sprintf("mapEntries %q %s", jsonFieldName(inField), d)
 	}
-	return false, nil, nil
 }
 
-func (fg *FileGenerator) GenerateMessageDefinition(prefix string, inMessage *descriptor.DescriptorProto) error {
-	typeName := prefix + inMessage.GetName()
+// mapFieldEncoder returns the field encoder expression for a map field.
+func (fg *FileGenerator) mapFieldEncoder(inField, keyField, valueField *descriptor.FieldDescriptorProto, val string) string {
+	key := fg.mapKey(keyField)
+	e := fg.fieldEncoderName(valueField)
+	switch {
+	case key.isBool:
+		return fmt.Sprintf("(boolMapEntriesFieldEncoder %q %s %s)", jsonFieldName(inField), e, val)
+	case key.toString != "":
+		return fmt.Sprintf("(keyedMapEntriesFieldEncoder %q %s %s %s)", jsonFieldName(inField), key.toString, e, val)
+	default:
+		return fmt.Sprintf("(mapEntriesFieldEncoder %q %s %s)", jsonFieldName(inField), e, val)
+	}
+}
+
+func (fg *FileGenerator) GenerateMessageDefinition(inMessage *descriptor.DescriptorProto) error {
+	typeName := fg.types.names.messages[inMessage]
 
 	fg.P("")
 	fg.P("")
@@ -59,24 +125,23 @@ func (fg *FileGenerator) GenerateMessageDefinition(prefix string, inMessage *des
 		}
 
 		for _, inField := range inMessage.GetField() {
-			if inField.OneofIndex != nil {
+			if isOneofField(inField) {
 				// Handled in the oneof only.
 				continue
 			}
 
-			optional := (inField.GetLabel() == descriptor.FieldDescriptorProto_LABEL_OPTIONAL) &&
-				(inField.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE)
+			optional := fg.isOptional(inField)
 			repeated := inField.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED
 
-			isMapEntries, mapKeyFieldDescriptor, mapValueFieldDescriptor := mapEntries(inField, inMessage)
+			isMapEntries, mapKeyFieldDescriptor, mapValueFieldDescriptor := fg.mapEntries(inField)
 
-			fType := fieldElmType(inField)
+			fType := fg.fieldElmType(inField)
 
-			fName := elmFieldName(inField.GetName())
+			fName := fg.types.names.fields[inField]
 			fNumber := inField.GetNumber()
 
 			if isMapEntries {
-				fg.P("%s %s : Dict.Dict %s %s -- %d", leading, fName, fieldElmType(mapKeyFieldDescriptor), fieldElmType(mapValueFieldDescriptor), fNumber)
+				fg.P("%s %s : %s -- %d", leading, fName, fg.mapFieldType(mapKeyFieldDescriptor, mapValueFieldDescriptor), fNumber)
 			} else if repeated {
 				fg.P("%s %s : List %s -- %d", leading, fName, fType, fNumber)
 			} else {
@@ -90,10 +155,12 @@ func (fg *FileGenerator) GenerateMessageDefinition(prefix string, inMessage *des
 			leading = ","
 		}
 
-		for _, inOneof := range inMessage.GetOneofDecl() {
-			oneofName := elmFieldName(inOneof.GetName())
-			// TODO: Prefix with message name to avoid collisions.
-			oneofTypeName := elmTypeName(inOneof.GetName())
+		for i, inOneof := range inMessage.GetOneofDecl() {
+			if isSyntheticOneof(inMessage, i) {
+				continue
+			}
+			oneofName := fg.types.names.oneofRecordFields[inOneof]
+			oneofTypeName := fg.types.names.oneofs[inOneof]
 			fg.P("%s %s : %s", leading, oneofName, oneofTypeName)
 
 			leading = ","
@@ -103,17 +170,20 @@ func (fg *FileGenerator) GenerateMessageDefinition(prefix string, inMessage *des
 		fg.Out()
 	}
 
-	for i, _ := range inMessage.GetOneofDecl() {
-		fg.GenerateOneofDefinition(prefix, inMessage, i)
-		fg.GenerateOneofDecoder(prefix, inMessage, i)
-		fg.GenerateOneofEncoder(prefix, inMessage, i)
+	for i := range inMessage.GetOneofDecl() {
+		if isSyntheticOneof(inMessage, i) {
+			continue
+		}
+		fg.GenerateOneofDefinition(inMessage, i)
+		fg.GenerateOneofDecoder(inMessage, i)
+		fg.GenerateOneofEncoder(inMessage, i)
 	}
 
 	return nil
 }
 
-func (fg *FileGenerator) GenerateMessageDecoder(prefix string, inMessage *descriptor.DescriptorProto) error {
-	typeName := prefix + inMessage.GetName()
+func (fg *FileGenerator) GenerateMessageDecoder(inMessage *descriptor.DescriptorProto) error {
+	typeName := fg.types.names.messages[inMessage]
 
 	fg.P("")
 	fg.P("")
@@ -126,22 +196,24 @@ func (fg *FileGenerator) GenerateMessageDecoder(prefix string, inMessage *descri
 			fg.In()
 
 			for _, inField := range inMessage.GetField() {
-				if inField.OneofIndex != nil {
+				if isOneofField(inField) {
 					// Handled in the oneof only.
 					continue
 				}
 
-				optional := (inField.GetLabel() == descriptor.FieldDescriptorProto_LABEL_OPTIONAL) &&
-					(inField.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE)
+				optional := fg.isOptional(inField)
+				required := fg.isRequired(inField)
 				repeated := inField.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED
-				isMapEntries, _, mapValueFieldDescriptor := mapEntries(inField, inMessage)
-				d := fieldDecoderName(inField)
-				def := fieldDefaultValue(inField)
+				isMapEntries, mapKeyFieldDescriptor, mapValueFieldDescriptor := fg.mapEntries(inField)
+				d := fg.fieldDecoderName(inField)
+				def := fg.fieldDefaultValue(inField)
 
 				if isMapEntries {
-					fg.P("|> mapEntries %q %s", jsonFieldName(inField), fieldDecoderName(mapValueFieldDescriptor))
+					fg.P("|> %s", fg.mapFieldDecoder(inField, mapKeyFieldDescriptor, mapValueFieldDescriptor))
 				} else if repeated {
 					fg.P("|> repeated %q %s", jsonFieldName(inField), d)
+				} else if required {
+					fg.P("|> requiredStrict %q %s", jsonFieldName(inField), d)
 				} else {
 					if optional {
 						fg.P("|> optional %q %s", jsonFieldName(inField), d)
@@ -151,8 +223,11 @@ func (fg *FileGenerator) GenerateMessageDecoder(prefix string, inMessage *descri
 				}
 			}
 
-			for _, inOneof := range inMessage.GetOneofDecl() {
-				oneofDecoderName := oneofDecoderName(inOneof)
+			for i, inOneof := range inMessage.GetOneofDecl() {
+				if isSyntheticOneof(inMessage, i) {
+					continue
+				}
+				oneofDecoderName := decoderName(fg.types.names.oneofs[inOneof])
 				fg.P("|> field %s", oneofDecoderName)
 			}
 
@@ -163,8 +238,8 @@ func (fg *FileGenerator) GenerateMessageDecoder(prefix string, inMessage *descri
 	return nil
 }
 
-func (fg *FileGenerator) GenerateMessageEncoder(prefix string, inMessage *descriptor.DescriptorProto) error {
-	typeName := prefix + inMessage.GetName()
+func (fg *FileGenerator) GenerateMessageEncoder(inMessage *descriptor.DescriptorProto) error {
+	typeName := fg.types.names.messages[inMessage]
 	argName := "v"
 
 	fg.P("")
@@ -184,37 +259,42 @@ func (fg *FileGenerator) GenerateMessageEncoder(prefix string, inMessage *descri
 			}
 
 			for _, inField := range inMessage.GetField() {
-				if inField.OneofIndex != nil {
+				if isOneofField(inField) {
 					// Handled in the oneof only.
 					continue
 				}
 
-				optional := (inField.GetLabel() == descriptor.FieldDescriptorProto_LABEL_OPTIONAL) &&
-					(inField.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE)
+				optional := fg.isOptional(inField)
+				required := fg.isRequired(inField)
 				repeated := inField.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED
-				isMapEntries, _, mapValueFieldDescriptor := mapEntries(inField, inMessage)
-				d := fieldEncoderName(inField)
-				val := argName + "." + elmFieldName(inField.GetName())
-				def := fieldDefaultValue(inField)
+				isMapEntries, mapKeyFieldDescriptor, mapValueFieldDescriptor := fg.mapEntries(inField)
+				d := fg.fieldEncoderName(inField)
+				val := argName + "." + fg.types.names.fields[inField]
+				def := fg.fieldDefaultValue(inField)
 
 				if isMapEntries {
-					fg.P("%s (mapEntriesFieldEncoder %q %s %s)", leading, jsonFieldName(inField), fieldEncoderName(mapValueFieldDescriptor), val)
+					fg.P("%s %s", leading, fg.mapFieldEncoder(inField, mapKeyFieldDescriptor, mapValueFieldDescriptor, val))
 				} else if repeated {
 					fg.P("%s (repeatedFieldEncoder %q %s %s)", leading, jsonFieldName(inField), d, val)
+				} else if required {
+					fg.P("%s (requiredStrictFieldEncoder %q %s %s)", leading, jsonFieldName(inField), d, val)
 				} else {
 					if optional {
 						fg.P("%s (optionalEncoder %q %s %s)", leading, jsonFieldName(inField), d, val)
 					} else {
-						fg.P("%s (requiredFieldEncoder %q %s %s %s)", leading, jsonFieldName(inField), d, def, val)
+						fg.P("%s (%s %q %s %s %s)", leading, fg.requiredFieldEncoderName(inField), jsonFieldName(inField), d, def, val)
 					}
 				}
 
 				leading = ","
 			}
 
-			for _, inOneof := range inMessage.GetOneofDecl() {
-				val := argName + "." + elmFieldName(inOneof.GetName())
-				oneofEncoderName := oneofEncoderName(inOneof)
+			for i, inOneof := range inMessage.GetOneofDecl() {
+				if isSyntheticOneof(inMessage, i) {
+					continue
+				}
+				val := argName + "." + fg.types.names.oneofRecordFields[inOneof]
+				oneofEncoderName := encoderName(fg.types.names.oneofs[inOneof])
 				fg.P("%s (%s %s)", leading, oneofEncoderName, val)
 				leading = ","
 			}
@@ -228,7 +308,109 @@ func (fg *FileGenerator) GenerateMessageEncoder(prefix string, inMessage *descri
 	return nil
 }
 
-func fieldElmType(inField *descriptor.FieldDescriptorProto) string {
+// GenerateFieldMaskPaths generates a record with the FieldMask path of each field of the message,
+// e.g. `fooPaths.barBaz == "bar_baz"`.
+func (fg *FileGenerator) GenerateFieldMaskPaths(inMessage *descriptor.DescriptorProto) {
+	typeName := fg.types.names.messages[inMessage]
+	name := firstLower(typeName) + "Paths"
+
+	fields := inMessage.GetField()
+	if len(fields) == 0 {
+		fg.P("")
+		fg.P("")
+		fg.P("%s : {}", name)
+		fg.P("%s =", name)
+		fg.In()
+		fg.P("{}")
+		fg.Out()
+		return
+	}
+
+	fg.P("")
+	fg.P("")
+	fg.P("%s :", name)
+	fg.In()
+	leading := "{"
+	for _, inField := range fields {
+		fg.P("%s %s : String", leading, fg.types.names.fields[inField])
+		leading = ","
+	}
+	fg.P("}")
+	fg.Out()
+	fg.P("%s =", name)
+	fg.In()
+	leading = "{"
+	for _, inField := range fields {
+		fg.P("%s %s = %q", leading, fg.types.names.fields[inField], inField.GetName())
+		leading = ","
+	}
+	fg.P("}")
+	fg.Out()
+}
+
+// isOptional returns whether the field is represented as a `Maybe` in the generated record.
+//
+// This is the case for singular message fields, proto3 `optional` fields, and other fields with
+// explicit presence (e.g. proto2 optional fields) that do not declare a default value; fields with a
+// default value use it in place of `Nothing`. Required fields, including message fields, are never
+// optional.
+func (fg *FileGenerator) isOptional(inField *descriptor.FieldDescriptorProto) bool {
+	if inField.GetLabel() != descriptor.FieldDescriptorProto_LABEL_OPTIONAL || fg.isRequired(inField) {
+		return false
+	}
+
+	// proto3 `optional` fields track presence explicitly.
+	if inField.GetProto3Optional() {
+		return true
+	}
+
+	switch inField.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		return true
+	}
+
+	return fg.features.fields[inField].fieldPresence == descriptor.FeatureSet_EXPLICIT && inField.DefaultValue == nil
+}
+
+// isRequired returns whether the field is a proto2 required field (or uses the equivalent
+// LEGACY_REQUIRED presence in editions), which must always be present on the wire.
+func (fg *FileGenerator) isRequired(inField *descriptor.FieldDescriptorProto) bool {
+	return inField.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REQUIRED ||
+		fg.features.fields[inField].fieldPresence == descriptor.FeatureSet_LEGACY_REQUIRED
+}
+
+// requiredFieldEncoderName returns the name of the function used to encode a field that is omitted
+// when it has its default value.
+func (fg *FileGenerator) requiredFieldEncoderName(inField *descriptor.FieldDescriptorProto) string {
+	// Values of type `Bytes.Bytes` cannot be compared with `==`.
+	if inField.GetType() == descriptor.FieldDescriptorProto_TYPE_BYTES && fg.options.Bytes == bytesElmBytes {
+		return "requiredElmBytesFieldEncoder"
+	}
+	return "requiredFieldEncoder"
+}
+
+// int64Type returns the representation of 64-bit integer fields when they are not represented as
+// `Int`.
+func (fg *FileGenerator) int64Type(inField *descriptor.FieldDescriptorProto) (int64Representation, bool) {
+	if fg.options.Int64 != int64String {
+		return int64Representation{}, false
+	}
+
+	switch inField.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return signedInt64, true
+	case descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return unsignedInt64, true
+	}
+
+	return int64Representation{}, false
+}
+
+func (fg *FileGenerator) fieldElmType(inField *descriptor.FieldDescriptorProto) string {
 	switch inField.GetType() {
 	case descriptor.FieldDescriptorProto_TYPE_INT32,
 		descriptor.FieldDescriptorProto_TYPE_INT64,
@@ -240,6 +422,9 @@ func fieldElmType(inField *descriptor.FieldDescriptorProto) string {
 		descriptor.FieldDescriptorProto_TYPE_FIXED64,
 		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
 		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		if t, ok := fg.int64Type(inField); ok {
+			return t.elmType
+		}
 		return "Int"
 	case descriptor.FieldDescriptorProto_TYPE_FLOAT,
 		descriptor.FieldDescriptorProto_TYPE_DOUBLE:
@@ -249,15 +434,18 @@ func fieldElmType(inField *descriptor.FieldDescriptorProto) string {
 	case descriptor.FieldDescriptorProto_TYPE_STRING:
 		return "String"
 	case descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP,
 		descriptor.FieldDescriptorProto_TYPE_ENUM:
 		// Well known types.
-		if n, ok := excludedTypes[inField.GetTypeName()]; ok {
-			return n
+		if t, ok := fg.wellKnownType(inField.GetTypeName()); ok {
+			return t.elmType
 		}
-		_, messageName := convert(inField.GetTypeName())
-		return messageName
+		messageName := fg.types.elmName(inField.GetTypeName())
+		return fg.qualified(inField.GetTypeName(), messageName)
 	case descriptor.FieldDescriptorProto_TYPE_BYTES:
-		// XXX
+		if fg.options.Bytes == bytesElmBytes {
+			return "Bytes.Bytes"
+		}
 		return "Bytes"
 	default:
 		// TODO: Return error.
@@ -265,7 +453,7 @@ func fieldElmType(inField *descriptor.FieldDescriptorProto) string {
 	}
 }
 
-func fieldEncoderName(inField *descriptor.FieldDescriptorProto) string {
+func (fg *FileGenerator) fieldEncoderName(inField *descriptor.FieldDescriptorProto) string {
 	switch inField.GetType() {
 	case descriptor.FieldDescriptorProto_TYPE_INT32,
 		descriptor.FieldDescriptorProto_TYPE_UINT32,
@@ -278,34 +466,45 @@ func fieldEncoderName(inField *descriptor.FieldDescriptorProto) string {
 		descriptor.FieldDescriptorProto_TYPE_SINT64,
 		descriptor.FieldDescriptorProto_TYPE_FIXED64,
 		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		if t, ok := fg.int64Type(inField); ok {
+			return t.encoder
+		}
 		return "numericStringEncoder"
 	case descriptor.FieldDescriptorProto_TYPE_FLOAT,
 		descriptor.FieldDescriptorProto_TYPE_DOUBLE:
-		return "JE.float"
+		return "floatEncoder"
 	case descriptor.FieldDescriptorProto_TYPE_BOOL:
 		return "JE.bool"
 	case descriptor.FieldDescriptorProto_TYPE_STRING:
 		return "JE.string"
 	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		// Well Known Types.
+		if t, ok := fg.wellKnownType(inField.GetTypeName()); ok {
+			return t.encoder
+		}
 		// TODO: Default enum value.
 		// Remove leading ".".
-		_, messageName := convert(inField.GetTypeName())
-		return encoderName(messageName)
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+		messageName := fg.types.elmName(inField.GetTypeName())
+		return fg.qualified(inField.GetTypeName(), encoderName(messageName))
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
 		// Well Known Types.
-		if n, ok := excludedEncoders[inField.GetTypeName()]; ok {
-			return n
+		if t, ok := fg.wellKnownType(inField.GetTypeName()); ok {
+			return t.encoder
 		}
-		_, messageName := convert(inField.GetTypeName())
-		return encoderName(messageName)
+		messageName := fg.types.elmName(inField.GetTypeName())
+		return fg.qualified(inField.GetTypeName(), encoderName(messageName))
 	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		if fg.options.Bytes == bytesElmBytes {
+			return "elmBytesFieldEncoder"
+		}
 		return "bytesFieldEncoder"
 	default:
 		return fmt.Sprintf("Error generating decoder for field %s", inField.GetType())
 	}
 }
 
-func fieldDecoderName(inField *descriptor.FieldDescriptorProto) string {
+func (fg *FileGenerator) fieldDecoderName(inField *descriptor.FieldDescriptorProto) string {
 	switch inField.GetType() {
 	case descriptor.FieldDescriptorProto_TYPE_INT32,
 		descriptor.FieldDescriptorProto_TYPE_INT64,
@@ -317,38 +516,54 @@ func fieldDecoderName(inField *descriptor.FieldDescriptorProto) string {
 		descriptor.FieldDescriptorProto_TYPE_FIXED64,
 		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
 		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		if t, ok := fg.int64Type(inField); ok {
+			return t.decoder
+		}
 		return "intDecoder"
 	case descriptor.FieldDescriptorProto_TYPE_FLOAT,
 		descriptor.FieldDescriptorProto_TYPE_DOUBLE:
-		return "JD.float"
+		return "floatDecoder"
 	case descriptor.FieldDescriptorProto_TYPE_BOOL:
 		return "JD.bool"
 	case descriptor.FieldDescriptorProto_TYPE_STRING:
 		return "JD.string"
 	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		// Well Known Types.
+		if t, ok := fg.wellKnownType(inField.GetTypeName()); ok {
+			return t.decoder
+		}
 		// TODO: Default enum value.
 		// Remove leading ".".
-		_, messageName := convert(inField.GetTypeName())
-		return decoderName(messageName)
-	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+		messageName := fg.types.elmName(inField.GetTypeName())
+		return fg.qualified(inField.GetTypeName(), decoderName(messageName))
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
 		// Well Known Types.
-		if n, ok := excludedDecoders[inField.GetTypeName()]; ok {
-			return n
+		if t, ok := fg.wellKnownType(inField.GetTypeName()); ok {
+			return t.decoder
 		}
-		_, messageName := convert(inField.GetTypeName())
-		return decoderName(messageName)
+		messageName := fg.types.elmName(inField.GetTypeName())
+		return fg.qualified(inField.GetTypeName(), decoderName(messageName))
 	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		if fg.options.Bytes == bytesElmBytes {
+			return "elmBytesFieldDecoder"
+		}
 		return "bytesFieldDecoder"
 	default:
 		return fmt.Sprintf("Error generating decoder for field %s", inField.GetType())
 	}
 }
 
-func fieldDefaultValue(inField *descriptor.FieldDescriptorProto) string {
+func (fg *FileGenerator) fieldDefaultValue(inField *descriptor.FieldDescriptorProto) string {
 	if inField.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
 		return "[]"
 	}
 
+	// Explicit proto2 default value.
+	if inField.DefaultValue != nil {
+		return fg.fieldExplicitDefaultValue(inField)
+	}
+
 	switch inField.GetType() {
 	case descriptor.FieldDescriptorProto_TYPE_INT32,
 		descriptor.FieldDescriptorProto_TYPE_INT64,
@@ -360,6 +575,9 @@ func fieldDefaultValue(inField *descriptor.FieldDescriptorProto) string {
 		descriptor.FieldDescriptorProto_TYPE_FIXED64,
 		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
 		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		if t, ok := fg.int64Type(inField); ok {
+			return t.zero
+		}
 		return "0"
 	case descriptor.FieldDescriptorProto_TYPE_FLOAT,
 		descriptor.FieldDescriptorProto_TYPE_DOUBLE:
@@ -369,14 +587,197 @@ func fieldDefaultValue(inField *descriptor.FieldDescriptorProto) string {
 	case descriptor.FieldDescriptorProto_TYPE_STRING:
 		return "\"\""
 	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		// Well Known Types and mapped types.
+		if def, ok := fg.enumDefault(inField.GetTypeName()); ok {
+			return def
+		}
 		// TODO: Default enum value.
-		_, messageName := convert(inField.GetTypeName())
-		return defaultEnumValue(messageName)
+		messageName := fg.types.elmName(inField.GetTypeName())
+		return fg.qualified(inField.GetTypeName(), defaultEnumValue(messageName))
 	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
 		return "xxx"
 	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		if fg.options.Bytes == bytesElmBytes {
+			return "emptyElmBytes"
+		}
 		return "[]"
 	default:
 		return fmt.Sprintf("Error generating decoder for field %s", inField.GetType())
 	}
 }
+
+// fieldExplicitDefaultValue converts the `[default = ...]` value of a proto2 field to an Elm
+// expression, which may be passed as an argument to a function.
+func (fg *FileGenerator) fieldExplicitDefaultValue(inField *descriptor.FieldDescriptorProto) string {
+	v := inField.GetDefaultValue()
+
+	switch inField.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		if t, ok := fg.int64Type(inField); ok {
+			return fmt.Sprintf("(Maybe.withDefault %s <| %s %q)", t.zero, t.fromString, v)
+		}
+		return elmNumberLiteral(v)
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT,
+		descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		switch v {
+		case "inf":
+			return "(1 / 0)"
+		case "-inf":
+			return "(-1 / 0)"
+		case "nan":
+			return "(0 / 0)"
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Sprintf("Error parsing default value %q for field %s", v, inField.GetName())
+		}
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return elmNumberLiteral(s)
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		if v == "true" {
+			return "True"
+		}
+		return "False"
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return elmStringLiteral(v)
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		// Well Known Types only have a single value, and the values of mapped types are unknown.
+		if def, ok := fg.enumDefault(inField.GetTypeName()); ok {
+			return def
+		}
+		// The default value is the name of one of the enum values.
+		value, err := fg.types.enumValue(inField.GetTypeName(), v)
+		if err != nil {
+			return fmt.Sprintf("Error generating default value for field %s: %v", inField.GetName(), err)
+		}
+		return fg.qualified(inField.GetTypeName(), value)
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		b, err := unescapeBytes(v)
+		if err != nil {
+			return fmt.Sprintf("Error parsing default value %q for field %s: %v", v, inField.GetName(), err)
+		}
+		list := "[]"
+		if len(b) > 0 {
+			elems := make([]string, len(b))
+			for i, c := range b {
+				elems[i] = strconv.Itoa(int(c))
+			}
+			list = "[ " + strings.Join(elems, ", ") + " ]"
+		}
+		if fg.options.Bytes == bytesElmBytes {
+			return "(elmBytesFromList " + list + ")"
+		}
+		return list
+	default:
+		return fmt.Sprintf("Error generating default value for field %s", inField.GetType())
+	}
+}
+
+// elmNumberLiteral wraps negative numbers in parentheses, so that they are not parsed as a
+// subtraction when used as function arguments.
+func elmNumberLiteral(v string) string {
+	if strings.HasPrefix(v, "-") {
+		return "(" + v + ")"
+	}
+	return v
+}
+
+func elmStringLiteral(v string) string {
+	b := &strings.Builder{}
+	b.WriteString("\"")
+	for _, r := range v {
+		switch r {
+		case '"':
+			b.WriteString("\\\"")
+		case '\\':
+			b.WriteString("\\\\")
+		case '\n':
+			b.WriteString("\\n")
+		case '\r':
+			b.WriteString("\\r")
+		case '\t':
+			b.WriteString("\\t")
+		default:
+			if unicode.IsPrint(r) {
+				b.WriteRune(r)
+			} else {
+				fmt.Fprintf(b, "\\u{%04X}", r)
+			}
+		}
+	}
+	b.WriteString("\"")
+	return b.String()
+}
+
+// unescapeBytes decodes the C-style escaping that protoc uses for default values of bytes fields.
+func unescapeBytes(v string) ([]byte, error) {
+	out := []byte{}
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		if c != '\\' {
+			out = append(out, c)
+			continue
+		}
+		i++
+		if i >= len(v) {
+			return nil, fmt.Errorf("trailing backslash")
+		}
+		switch c = v[i]; c {
+		case 'n':
+			out = append(out, '\n')
+		case 'r':
+			out = append(out, '\r')
+		case 't':
+			out = append(out, '\t')
+		case 'a':
+			out = append(out, '\a')
+		case 'b':
+			out = append(out, '\b')
+		case 'f':
+			out = append(out, '\f')
+		case 'v':
+			out = append(out, '\v')
+		case '\\', '\'', '"', '?':
+			out = append(out, c)
+		case 'x', 'X':
+			j := i + 1
+			for j < len(v) && j < i+3 && isHexDigit(v[j]) {
+				j++
+			}
+			n, err := strconv.ParseUint(v[i+1:j], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hex escape")
+			}
+			out = append(out, byte(n))
+			i = j - 1
+		default:
+			j := i
+			for j < len(v) && j < i+3 && v[j] >= '0' && v[j] <= '7' {
+				j++
+			}
+			n, err := strconv.ParseUint(v[i:j], 8, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid escape sequence")
+			}
+			out = append(out, byte(n))
+			i = j - 1
+		}
+	}
+	return out, nil
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
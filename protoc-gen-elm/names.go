@@ -0,0 +1,370 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// elmNames holds the Elm names of the types, constructors and record fields generated for the
+// elements of each file, after resolving collisions between them.
+//
+// The names of decoders, encoders and other values are derived from the type names, so they are
+// unique as long as the type names are.
+type elmNames struct {
+	messages   map[*descriptor.DescriptorProto]string
+	enums      map[*descriptor.EnumDescriptorProto]string
+	enumValues map[*descriptor.EnumValueDescriptorProto]string
+	// Constructors for unknown values of open enums, with the `unknown_enum_values=preserve`
+	// option.
+	unrecognizedEnumValues map[*descriptor.EnumDescriptorProto]string
+	// Union types generated for oneofs, and their variant for when no field is set.
+	oneofs            map[*descriptor.OneofDescriptorProto]string
+	oneofUnspecified  map[*descriptor.OneofDescriptorProto]string
+	oneofVariants     map[*descriptor.FieldDescriptorProto]string
+	fields            map[*descriptor.FieldDescriptorProto]string
+	oneofRecordFields map[*descriptor.OneofDescriptorProto]string
+}
+
+func newElmNames() *elmNames {
+	return &elmNames{
+		messages:               map[*descriptor.DescriptorProto]string{},
+		enums:                  map[*descriptor.EnumDescriptorProto]string{},
+		enumValues:             map[*descriptor.EnumValueDescriptorProto]string{},
+		unrecognizedEnumValues: map[*descriptor.EnumDescriptorProto]string{},
+		oneofs:                 map[*descriptor.OneofDescriptorProto]string{},
+		oneofUnspecified:       map[*descriptor.OneofDescriptorProto]string{},
+		oneofVariants:          map[*descriptor.FieldDescriptorProto]string{},
+		fields:                 map[*descriptor.FieldDescriptorProto]string{},
+		oneofRecordFields:      map[*descriptor.OneofDescriptorProto]string{},
+	}
+}
+
+// symbol is an Elm name generated for a proto element, before collisions are resolved.
+type symbol struct {
+	// Description of the proto element, used in error messages, e.g. `message Foo.Bar`.
+	desc string
+	name string
+	// Namespaces in which the name must be unique.
+	namespaces []*namespace
+	// Name to use instead if the name collides with another one, for enum values whose prefix is
+	// stripped.
+	fallback string
+	// Records the final name.
+	assign func(string)
+}
+
+// namespace is a set of Elm names that must be unique, e.g. the types of a module.
+type namespace struct {
+	// Number of symbols requesting each name; renamed symbols must avoid all of them.
+	requested map[string]int
+	// Descriptions of the elements to which names were assigned.
+	owners map[string]string
+}
+
+func newNamespace() *namespace {
+	return &namespace{
+		requested: map[string]int{},
+		owners:    map[string]string{},
+	}
+}
+
+// addFile assigns names to all the elements of the file, resolving collisions according to the
+// `name_collisions` option. In error mode, the returned error lists all the collisions, and the
+// colliding names are left as they are.
+func (names *elmNames) addFile(inFile *descriptor.FileDescriptorProto, options *Options) error {
+	types := newNamespace()
+	constructors := newNamespace()
+	features := resolveFeatures(inFile)
+
+	// Symbols are resolved in order, so types keep their names in preference to constructors,
+	// which are more likely to be renamed.
+	var typeSymbols, constructorSymbols, fieldSymbols []*symbol
+	// Values of the enums whose prefix is stripped, by enum.
+	var strippedEnums [][]*symbol
+
+	var addEnum func(prefix string, inEnum *descriptor.EnumDescriptorProto, scope string)
+	addEnum = func(prefix string, inEnum *descriptor.EnumDescriptorProto, scope string) {
+		typeSymbols = append(typeSymbols, &symbol{
+			desc:       "enum " + scope + inEnum.GetName(),
+			name:       avoidReservedTypeName(prefix + inEnum.GetName()),
+			namespaces: []*namespace{types},
+			assign:     func(n string) { names.enums[inEnum] = n },
+		})
+		stripped, strip := enumValuesWithoutPrefix(inEnum)
+		strip = strip && options.StripEnumPrefix
+		for i := range stripped {
+			// Keep the prefix rather than adding a suffix, e.g. for `FLAG_TRUE`.
+			n := prefix + elmEnumValueName(stripped[i])
+			if avoidReservedTypeName(n) != n {
+				strip = false
+			}
+		}
+		var values []*symbol
+		for i, inValue := range inEnum.GetValue() {
+			inValue := inValue
+			s := &symbol{
+				desc:       "enum value " + scope + inEnum.GetName() + "." + inValue.GetName(),
+				name:       avoidReservedTypeName(prefix + elmEnumValueName(inValue.GetName())),
+				namespaces: []*namespace{constructors},
+				assign:     func(n string) { names.enumValues[inValue] = n },
+			}
+			if strip {
+				s.fallback = s.name
+				s.name = prefix + elmEnumValueName(stripped[i])
+			}
+			values = append(values, s)
+		}
+		constructorSymbols = append(constructorSymbols, values...)
+		if options.UnknownEnumValues == unknownEnumValuesPreserve && features.enums[inEnum].enumType == descriptor.FeatureSet_OPEN {
+			constructorSymbols = append(constructorSymbols, &symbol{
+				desc:       "enum " + scope + inEnum.GetName(),
+				name:       prefix + inEnum.GetName() + "Unrecognized_",
+				namespaces: []*namespace{constructors},
+				assign:     func(n string) { names.unrecognizedEnumValues[inEnum] = n },
+			})
+		}
+		if strip {
+			strippedEnums = append(strippedEnums, values)
+		}
+	}
+
+	var addMessage func(prefix string, inMessage *descriptor.DescriptorProto, scope string)
+	addMessage = func(prefix string, inMessage *descriptor.DescriptorProto, scope string) {
+		typeName := prefix + inMessage.GetName()
+		protoName := scope + inMessage.GetName()
+
+		// Record type aliases also define a constructor.
+		typeSymbols = append(typeSymbols, &symbol{
+			desc:       "message " + protoName,
+			name:       avoidReservedTypeName(typeName),
+			namespaces: []*namespace{types, constructors},
+			assign:     func(n string) { names.messages[inMessage] = n },
+		})
+
+		fields := newNamespace()
+		for _, inField := range inMessage.GetField() {
+			inField := inField
+			fieldSymbols = append(fieldSymbols, &symbol{
+				desc:       "field " + protoName + "." + inField.GetName(),
+				name:       elmFieldName(inField.GetName()),
+				namespaces: []*namespace{fields},
+				assign:     func(n string) { names.fields[inField] = n },
+			})
+		}
+
+		for i, inOneof := range inMessage.GetOneofDecl() {
+			if isSyntheticOneof(inMessage, i) {
+				continue
+			}
+			inOneof := inOneof
+			oneofDesc := "oneof " + protoName + "." + inOneof.GetName()
+			typeSymbols = append(typeSymbols, &symbol{
+				desc:       oneofDesc,
+				name:       oneofType(typeName, inOneof),
+				namespaces: []*namespace{types},
+				assign:     func(n string) { names.oneofs[inOneof] = n },
+			})
+			constructorSymbols = append(constructorSymbols, &symbol{
+				desc:       oneofDesc,
+				name:       oneofUnspecifiedValue(typeName, inOneof),
+				namespaces: []*namespace{constructors},
+				assign:     func(n string) { names.oneofUnspecified[inOneof] = n },
+			})
+			fieldSymbols = append(fieldSymbols, &symbol{
+				desc:       oneofDesc,
+				name:       elmFieldName(inOneof.GetName()),
+				namespaces: []*namespace{fields},
+				assign:     func(n string) { names.oneofRecordFields[inOneof] = n },
+			})
+			for _, inField := range inMessage.GetField() {
+				if inField.OneofIndex == nil || inField.GetOneofIndex() != int32(i) {
+					continue
+				}
+				inField := inField
+				constructorSymbols = append(constructorSymbols, &symbol{
+					desc:       "oneof field " + protoName + "." + inField.GetName(),
+					name:       oneofVariantName(typeName, inOneof, inField, options),
+					namespaces: []*namespace{constructors},
+					assign:     func(n string) { names.oneofVariants[inField] = n },
+				})
+			}
+		}
+
+		for _, inEnum := range inMessage.GetEnumType() {
+			addEnum(typeName+"_", inEnum, protoName+".")
+		}
+		for _, nested := range inMessage.GetNestedType() {
+			addMessage(typeName+"_", nested, protoName+".")
+		}
+	}
+
+	for _, inEnum := range inFile.GetEnumType() {
+		addEnum("", inEnum, "")
+	}
+	for _, inMessage := range inFile.GetMessageType() {
+		addMessage("", inMessage, "")
+	}
+
+	symbols := append(append(typeSymbols, constructorSymbols...), fieldSymbols...)
+	for _, s := range symbols {
+		s.request(1)
+	}
+
+	// Keep the prefix of all the values of an enum if any of them would collide without it, with
+	// the names requested by any other symbol.
+	var collidingEnums [][]*symbol
+	for _, values := range strippedEnums {
+		for _, s := range values {
+			if s.requestedByOthers() {
+				collidingEnums = append(collidingEnums, values)
+				break
+			}
+		}
+	}
+	for _, values := range collidingEnums {
+		for _, s := range values {
+			s.request(-1)
+			s.name = s.fallback
+			s.request(1)
+		}
+	}
+
+	return resolveSymbols(symbols, options, func(format string, a ...interface{}) error {
+		return fileErrorf(inFile, format, a...)
+	})
+}
+
+// resolveSymbols assigns the final names of the symbols, whose names must already be requested,
+// in order. Collisions are resolved according to the `name_collisions` option; in error mode, the
+// returned error lists all the collisions, formatted with errorf.
+func resolveSymbols(symbols []*symbol, options *Options, errorf func(format string, a ...interface{}) error) error {
+	var errs errorList
+	for _, s := range symbols {
+		name := s.name
+		if other, taken := s.owner(name); taken {
+			if options.NameCollisions == nameCollisionsError {
+				errs = append(errs, errorf("%s and %s both generate the Elm name %s", other, s.desc, name))
+			} else {
+				name = s.rename()
+			}
+		}
+		for _, ns := range s.namespaces {
+			if _, ok := ns.owners[name]; !ok {
+				ns.owners[name] = s.desc
+			}
+		}
+		s.assign(name)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (s *symbol) requestedByOthers() bool {
+	for _, ns := range s.namespaces {
+		if ns.requested[s.name] > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *symbol) request(n int) {
+	for _, ns := range s.namespaces {
+		ns.requested[s.name] += n
+	}
+}
+
+// owner returns the description of the element that already uses the name in one of the
+// namespaces of the symbol, if any.
+func (s *symbol) owner(name string) (string, bool) {
+	for _, ns := range s.namespaces {
+		if other, ok := ns.owners[name]; ok {
+			return other, true
+		}
+	}
+	return "", false
+}
+
+// rename returns the first name of the form `<name>_<n>` that is neither used nor requested by
+// another symbol, e.g. `Foo_Bar_1`.
+func (s *symbol) rename() string {
+	for n := 1; ; n++ {
+		name := s.name + "_" + strconv.Itoa(n)
+		free := true
+		for _, ns := range s.namespaces {
+			if ns.requested[name] > 0 || ns.owners[name] != "" {
+				free = false
+			}
+		}
+		if free {
+			return name
+		}
+	}
+}
+
+// elmName returns the Elm type name of a message or enum, given its fully-qualified proto name.
+func (types typeIndex) elmName(typeName string) string {
+	if t, ok := types.byName[typeName]; ok {
+		if t.message != nil {
+			return types.names.messages[t.message]
+		}
+		return types.names.enums[t.enum]
+	}
+	_, messageName := convert(typeName)
+	return messageName
+}
+
+// enumValue returns the Elm constructor of the value with the given name of an enum, given the
+// fully-qualified proto name of the enum.
+func (types typeIndex) enumValue(typeName string, valueName string) (string, error) {
+	t, ok := types.byName[typeName]
+	if !ok || t.enum == nil {
+		return "", fmt.Errorf("unknown enum %s", typeName)
+	}
+	for _, inValue := range t.enum.GetValue() {
+		if inValue.GetName() == valueName {
+			return types.names.enumValues[inValue], nil
+		}
+	}
+	return "", fmt.Errorf("unknown value %s for enum %s", valueName, typeName)
+}
+
+// enumValuesWithoutPrefix returns the names of the values of an enum without the prefix derived
+// from the name of the enum, e.g. `RED` for `COLOR_RED` in enum `Color` or `PHONE_TYPE_HOME` in
+// enum `PhoneType`, or false if any of the values does not start with the prefix followed by a
+// letter.
+func enumValuesWithoutPrefix(inEnum *descriptor.EnumDescriptorProto) ([]string, bool) {
+	out := []string{}
+	for _, inValue := range inEnum.GetValue() {
+		name := inValue.GetName()
+		i := 0
+		for _, r := range inEnum.GetName() {
+			if r == '_' {
+				continue
+			}
+			for i < len(name) && name[i] == '_' {
+				i++
+			}
+			if i >= len(name) || unicode.ToUpper(rune(name[i])) != unicode.ToUpper(r) {
+				return nil, false
+			}
+			i++
+		}
+		if i >= len(name) || name[i] != '_' {
+			return nil, false
+		}
+		rest := strings.TrimLeft(name[i:], "_")
+		if rest == "" || !unicode.IsLetter(rune(rest[0])) {
+			return nil, false
+		}
+		out = append(out, rest)
+	}
+	return out, true
+}
@@ -2,11 +2,10 @@ package main
 
 import "github.com/golang/protobuf/protoc-gen-go/descriptor"
 
-func (fg *FileGenerator) GenerateOneofDefinition(prefix string, inMessage *descriptor.DescriptorProto, oneofIndex int) error {
+func (fg *FileGenerator) GenerateOneofDefinition(inMessage *descriptor.DescriptorProto, oneofIndex int) error {
 	inOneof := inMessage.GetOneofDecl()[oneofIndex]
 
-	// TODO: Prefix with message name to avoid collisions.
-	oneofType := oneofType(inOneof)
+	oneofType := fg.types.names.oneofs[inOneof]
 
 	fg.P("")
 	fg.P("")
@@ -16,15 +15,15 @@ func (fg *FileGenerator) GenerateOneofDefinition(prefix string, inMessage *descr
 
 		leading := "="
 		{
-			oneofVariantName := oneofUnspecifiedValue(inOneof)
+			oneofVariantName := fg.types.names.oneofUnspecified[inOneof]
 			fg.P("%s %s", leading, oneofVariantName)
 			leading = "|"
 		}
 		for _, inField := range inMessage.GetField() {
 			if inField.OneofIndex != nil && inField.GetOneofIndex() == int32(oneofIndex) {
 
-				oneofVariantName := elmTypeName(inField.GetName())
-				oneofArgumentType := fieldElmType(inField)
+				oneofVariantName := fg.types.names.oneofVariants[inField]
+				oneofArgumentType := fg.fieldElmType(inField)
 				fg.P("%s %s %s", leading, oneofVariantName, oneofArgumentType)
 
 				leading = "|"
@@ -36,12 +35,11 @@ func (fg *FileGenerator) GenerateOneofDefinition(prefix string, inMessage *descr
 	return nil
 }
 
-func (fg *FileGenerator) GenerateOneofDecoder(prefix string, inMessage *descriptor.DescriptorProto, oneofIndex int) error {
+func (fg *FileGenerator) GenerateOneofDecoder(inMessage *descriptor.DescriptorProto, oneofIndex int) error {
 	inOneof := inMessage.GetOneofDecl()[oneofIndex]
 
-	// TODO: Prefix with message name to avoid collisions.
-	oneofType := oneofType(inOneof)
-	decoderName := oneofDecoderName(inOneof)
+	oneofType := fg.types.names.oneofs[inOneof]
+	decoderName := decoderName(oneofType)
 
 	fg.P("")
 	fg.P("")
@@ -56,13 +54,13 @@ func (fg *FileGenerator) GenerateOneofDecoder(prefix string, inMessage *descript
 			leading := "["
 			for _, inField := range inMessage.GetField() {
 				if inField.OneofIndex != nil && inField.GetOneofIndex() == int32(oneofIndex) {
-					oneofVariantName := elmTypeName(inField.GetName())
-					decoderName := fieldDecoderName(inField)
+					oneofVariantName := fg.types.names.oneofVariants[inField]
+					decoderName := fg.fieldDecoderName(inField)
 					fg.P("%s JD.map %s (JD.field %q %s)", leading, oneofVariantName, inField.GetJsonName(), decoderName)
 					leading = ","
 				}
 			}
-			fg.P("%s JD.succeed %s", leading, oneofUnspecifiedValue(inOneof))
+			fg.P("%s JD.succeed %s", leading, fg.types.names.oneofUnspecified[inOneof])
 			fg.P("]")
 			fg.Out()
 		}
@@ -72,12 +70,11 @@ func (fg *FileGenerator) GenerateOneofDecoder(prefix string, inMessage *descript
 	return nil
 }
 
-func (fg *FileGenerator) GenerateOneofEncoder(prefix string, inMessage *descriptor.DescriptorProto, oneofIndex int) error {
+func (fg *FileGenerator) GenerateOneofEncoder(inMessage *descriptor.DescriptorProto, oneofIndex int) error {
 	inOneof := inMessage.GetOneofDecl()[oneofIndex]
 
-	// TODO: Prefix with message name to avoid collisions.
-	oneofType := oneofType(inOneof)
-	encoderName := oneofEncoderName(inOneof)
+	oneofType := fg.types.names.oneofs[inOneof]
+	encoderName := encoderName(oneofType)
 	argName := "v"
 
 	fg.P("")
@@ -92,7 +89,7 @@ func (fg *FileGenerator) GenerateOneofEncoder(prefix string, inMessage *descript
 
 			valueName := "x"
 			{
-				oneofVariantName := oneofUnspecifiedValue(inOneof)
+				oneofVariantName := fg.types.names.oneofUnspecified[inOneof]
 				fg.P("%s ->", oneofVariantName)
 				fg.In()
 				fg.P("Nothing")
@@ -102,8 +99,8 @@ func (fg *FileGenerator) GenerateOneofEncoder(prefix string, inMessage *descript
 			// https://developers.google.com/protocol-buffers/docs/proto3#oneof
 			for _, inField := range inMessage.GetField() {
 				if inField.OneofIndex != nil && inField.GetOneofIndex() == int32(oneofIndex) {
-					oneofVariantName := elmTypeName(inField.GetName())
-					e := fieldEncoderName(inField)
+					oneofVariantName := fg.types.names.oneofVariants[inField]
+					e := fg.fieldEncoderName(inField)
 					fg.P("%s %s ->", oneofVariantName, valueName)
 					fg.In()
 					fg.P("Just ( %q, %s %s )", inField.GetJsonName(), e, valueName)
@@ -118,20 +115,39 @@ func (fg *FileGenerator) GenerateOneofEncoder(prefix string, inMessage *descript
 	return nil
 }
 
-func oneofDecoderName(inOneof *descriptor.OneofDescriptorProto) string {
-	typeName := elmTypeName(inOneof.GetName())
-	return decoderName(typeName)
+// oneofType returns the name of the union type of a oneof, prefixed with the name of the message
+// that contains it, e.g. `Foo_Kind` for `oneof kind` in message `Foo`.
+func oneofType(typeName string, inOneof *descriptor.OneofDescriptorProto) string {
+	return typeName + "_" + elmTypeName(inOneof.GetName())
 }
 
-func oneofEncoderName(inOneof *descriptor.OneofDescriptorProto) string {
-	typeName := elmTypeName(inOneof.GetName())
-	return encoderName(typeName)
+func oneofUnspecifiedValue(typeName string, inOneof *descriptor.OneofDescriptorProto) string {
+	return typeName + "_" + elmTypeName(inOneof.GetName()+"_unspecified")
 }
 
-func oneofType(inOneof *descriptor.OneofDescriptorProto) string {
-	return elmTypeName(inOneof.GetName())
+// oneofVariantName returns the name of the variant of a oneof field, prefixed with the name of the
+// message that contains it, e.g. `Foo_Name` for field `name` in message `Foo`, or also with the
+// name of the oneof with the `oneof_variants=oneof` option, e.g. `Foo_Kind_Name` for `oneof kind`.
+func oneofVariantName(typeName string, inOneof *descriptor.OneofDescriptorProto, inField *descriptor.FieldDescriptorProto, options *Options) string {
+	if options.OneofVariants == oneofVariantsOneof {
+		return oneofType(typeName, inOneof) + "_" + elmTypeName(inField.GetName())
+	}
+	return typeName + "_" + elmTypeName(inField.GetName())
+}
+
+// isOneofField returns whether the field is part of a real oneof, which is generated as a union
+// type. proto3 `optional` fields are instead generated as `Maybe` fields.
+func isOneofField(inField *descriptor.FieldDescriptorProto) bool {
+	return inField.OneofIndex != nil && !inField.GetProto3Optional()
 }
 
-func oneofUnspecifiedValue(inOneof *descriptor.OneofDescriptorProto) string {
-	return elmTypeName(inOneof.GetName() + "_unspecified")
+// isSyntheticOneof returns whether the oneof was synthesized by protoc to track the presence of a
+// proto3 `optional` field.
+func isSyntheticOneof(inMessage *descriptor.DescriptorProto, oneofIndex int) bool {
+	for _, inField := range inMessage.GetField() {
+		if inField.OneofIndex != nil && inField.GetOneofIndex() == int32(oneofIndex) {
+			return inField.GetProto3Optional()
+		}
+	}
+	return false
 }
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Options controls the behaviour of the generator for a single protoc invocation.
+//
+// Options are passed to the plugin as a comma-separated list of `key=value` pairs, either as a
+// prefix of the output directory or via a separate flag:
+//
+//	protoc --elm_out=debug=true:. *.proto
+//	protoc --elm_opt=debug=true --elm_out=. *.proto
+//
+// Boolean options may omit the value, in which case it defaults to `true`.
+type Options struct {
+	// Log the incoming request and progress information to STDERR.
+	Debug bool
+
+	// Elm type used for bytes fields: either bytesList (the default) or bytesElmBytes.
+	Bytes string
+
+	// Elm type used for 64-bit integer fields: either int64Int (the default) or int64String.
+	Int64 string
+
+	// Generate a record with the FieldMask path of each field, for each message.
+	FieldMaskPaths bool
+
+	// Also generate modules for the google/protobuf/*.proto dependencies that are not mapped to
+	// types of the runtime library, e.g. api.proto or descriptor.proto.
+	WellKnownModules bool
+
+	// Custom mappings of proto types to existing Elm types, keyed by fully-qualified type name.
+	TypeMappings map[string]typeMapping
+
+	// How Elm module names are derived from proto files: either moduleNamingPath (the default) or
+	// moduleNamingPackage.
+	ModuleNaming string
+
+	// Elm module names to use for specific proto files, keyed by file name, taking precedence
+	// over ModuleNaming.
+	ModuleOverrides map[string]string
+
+	// How open enums (e.g. proto3 enums) decode values that are not known to the generated code:
+	// either unknownEnumValuesFirst (the default), unknownEnumValuesPreserve or
+	// unknownEnumValuesFail. Closed enums always fail.
+	UnknownEnumValues string
+
+	// Remove the name of the enum from the names of its values when possible, e.g. `Red` rather
+	// than `ColorRed` for `COLOR_RED` in enum `Color`.
+	StripEnumPrefix bool
+
+	// Prefix of the variants of oneof union types: either oneofVariantsMessage (the default) or
+	// oneofVariantsOneof.
+	OneofVariants string
+
+	// What to do when several elements of a file generate the same Elm name: either
+	// nameCollisionsRename (the default) or nameCollisionsError.
+	NameCollisions string
+
+	// Name of an additional Elm module to generate, containing a union of all the message types
+	// that can be packed in a `google.protobuf.Any`, or empty to not generate it.
+	AnyRegistry string
+}
+
+const (
+	// Represent bytes as `List Int`, via the `Protobuf.Bytes` alias.
+	bytesList = "list"
+	// Represent bytes as `Bytes.Bytes` from the elm/bytes package.
+	bytesElmBytes = "elm_bytes"
+
+	// Represent 64-bit integers as `Int`, which loses precision above 2^53.
+	int64Int = "int"
+	// Represent 64-bit integers as the string-backed `Int64` and `UInt64` types of the runtime
+	// library, which preserve the full range.
+	int64String = "string"
+
+	// Derive module names from the path of the proto file, e.g. `Foo.Bar` for `foo/bar.proto`.
+	moduleNamingPath = "path"
+	// Derive module names from the package and base name of the proto file, e.g.
+	// `Acme.Billing.V1.Invoice` for `invoice.proto` in package `acme.billing.v1`.
+	moduleNamingPackage = "package"
+
+	// Decode unknown enum values as the first value of the enum.
+	unknownEnumValuesFirst = "first"
+	// Decode unknown enum values as an additional `<Enum>Unrecognized_ String` constructor, which
+	// is encoded back unchanged.
+	unknownEnumValuesPreserve = "preserve"
+	// Fail to decode unknown enum values.
+	unknownEnumValuesFail = "fail"
+
+	// Prefix oneof variants with the name of the message, e.g. `Foo_Name`.
+	oneofVariantsMessage = "message"
+	// Prefix oneof variants with the name of the message and of the oneof, e.g. `Foo_Kind_Name`,
+	// so that they cannot clash with other oneofs, nested messages or enum values.
+	oneofVariantsOneof = "oneof"
+
+	// Append a numeric suffix to the names of the elements that collide with previous ones, e.g.
+	// `Foo_Bar_1`; types keep their names in preference to constructors.
+	nameCollisionsRename = "rename"
+	// Report colliding names as errors.
+	nameCollisionsError = "error"
+)
+
+// optionSetters maps each known option key to a function that validates its value and stores it
+// in the Options struct.
+var optionSetters = map[string]func(o *Options, value string) error{
+	"debug": func(o *Options, value string) error {
+		return parseBoolOption(&o.Debug, value)
+	},
+	"bytes": func(o *Options, value string) error {
+		return parseEnumOption(&o.Bytes, value, bytesList, bytesElmBytes)
+	},
+	"int64": func(o *Options, value string) error {
+		return parseEnumOption(&o.Int64, value, int64Int, int64String)
+	},
+	"field_mask_paths": func(o *Options, value string) error {
+		return parseBoolOption(&o.FieldMaskPaths, value)
+	},
+	"well_known_modules": func(o *Options, value string) error {
+		return parseBoolOption(&o.WellKnownModules, value)
+	},
+	"type_mappings": func(o *Options, value string) error {
+		m, err := loadTypeMappings(value)
+		if err != nil {
+			return err
+		}
+		o.TypeMappings = m
+		return nil
+	},
+	"module_naming": func(o *Options, value string) error {
+		return parseEnumOption(&o.ModuleNaming, value, moduleNamingPath, moduleNamingPackage)
+	},
+	// May be repeated, e.g. `elm_module=foo.proto=Acme.Foo,elm_module=bar.proto=Acme.Bar`.
+	"elm_module": func(o *Options, value string) error {
+		i := strings.Index(value, "=")
+		if i < 0 || !strings.HasSuffix(value[:i], ".proto") {
+			return fmt.Errorf("expected <file.proto>=<Elm.Module>")
+		}
+		fileName, moduleName := value[:i], value[i+1:]
+		if !isElmModuleName(moduleName) {
+			return fmt.Errorf("expected an Elm module name for %s", fileName)
+		}
+		o.ModuleOverrides[fileName] = moduleName
+		return nil
+	},
+	"unknown_enum_values": func(o *Options, value string) error {
+		return parseEnumOption(&o.UnknownEnumValues, value, unknownEnumValuesFirst, unknownEnumValuesPreserve, unknownEnumValuesFail)
+	},
+	"strip_enum_prefix": func(o *Options, value string) error {
+		return parseBoolOption(&o.StripEnumPrefix, value)
+	},
+	"oneof_variants": func(o *Options, value string) error {
+		return parseEnumOption(&o.OneofVariants, value, oneofVariantsMessage, oneofVariantsOneof)
+	},
+	"name_collisions": func(o *Options, value string) error {
+		return parseEnumOption(&o.NameCollisions, value, nameCollisionsRename, nameCollisionsError)
+	},
+	"any_registry": func(o *Options, value string) error {
+		return parseModuleNameOption(&o.AnyRegistry, value)
+	},
+}
+
+// parseOptions parses the parameter string from the CodeGeneratorRequest.
+func parseOptions(parameter string) (*Options, error) {
+	o := &Options{
+		Bytes:             bytesList,
+		Int64:             int64Int,
+		ModuleNaming:      moduleNamingPath,
+		ModuleOverrides:   map[string]string{},
+		UnknownEnumValues: unknownEnumValuesFirst,
+		OneofVariants:     oneofVariantsMessage,
+		NameCollisions:    nameCollisionsRename,
+	}
+
+	for _, kv := range strings.Split(parameter, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+
+		key, value := kv, ""
+		if i := strings.Index(kv, "="); i >= 0 {
+			key, value = kv[:i], kv[i+1:]
+		}
+
+		set, ok := optionSetters[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown option %q (known options: %s)", key, strings.Join(knownOptions(), ", "))
+		}
+
+		err := set(o, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for option %q: %v", value, key, err)
+		}
+	}
+
+	return o, nil
+}
+
+func knownOptions() []string {
+	keys := []string{}
+	for k := range optionSetters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func parseBoolOption(out *bool, value string) error {
+	if value == "" {
+		*out = true
+		return nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("expected a boolean")
+	}
+	*out = b
+	return nil
+}
+
+// parseEnumOption accepts exactly one of the allowed values.
+func parseEnumOption(out *string, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			*out = value
+			return nil
+		}
+	}
+	return fmt.Errorf("expected one of: %s", strings.Join(allowed, ", "))
+}
+
+// parseModuleNameOption accepts a valid Elm module name, e.g. `Acme.Registry`.
+func parseModuleNameOption(out *string, value string) error {
+	if !isElmModuleName(value) {
+		return fmt.Errorf("expected an Elm module name")
+	}
+	*out = value
+	return nil
+}
+
+func isElmModuleName(s string) bool {
+	for _, segment := range strings.Split(s, ".") {
+		if !isElmTypeIdentifier(segment) {
+			return false
+		}
+	}
+	return true
+}
+
+// isElmTypeIdentifier returns whether s starts with an upper case letter, followed by letters,
+// digits or underscores.
+func isElmTypeIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if i == 0 && !unicode.IsUpper(r) {
+			return false
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// isElmValueIdentifier returns whether s starts with a lower case letter, followed by letters,
+// digits or underscores.
+func isElmValueIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if i == 0 && !unicode.IsLower(r) {
+			return false
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// inlinedWellKnownTypes are the Well Known Types whose JSON representation is a regular object,
+// and are therefore inlined in an Any like other messages.
+var inlinedWellKnownTypes = map[string]bool{
+	".google.protobuf.Empty": true,
+}
+
+// anyRegistryEntry is a message type that can be packed in a `google.protobuf.Any`.
+type anyRegistryEntry struct {
+	// Fully-qualified name of the type, without the leading dot, as used in type URLs.
+	protoName string
+	// Name of the variant of the generated union type.
+	variant string
+	// Qualified Elm type, decoder and encoder of the message.
+	elmType string
+	decoder string
+	encoder string
+	// Well Known Types with a special JSON representation are stored in the `value` field of the
+	// Any, rather than inlined.
+	wellKnown bool
+	// Module to import for Well Known Types mapped by the `type_mappings` option.
+	mappedModule string
+}
+
+// generateAnyRegistry generates a module with a union of all the message types defined in the
+// given files and of the Well Known Types, and functions to convert them to and from an `Any`.
+//
+// Generated messages refer to `Any` fields through the runtime type, so that their modules do not
+// need to import the registry, which in turn imports all of them.
+func generateAnyRegistry(inFiles []*descriptor.FileDescriptorProto, types typeIndex, options *Options) (*plugin.CodeGeneratorResponse_File, error) {
+	b := &bytes.Buffer{}
+	fg := NewFileGenerator(b, "", types, nil, options)
+
+	var entries []anyRegistryEntry
+	for _, inFile := range inFiles {
+		entries = append(entries, anyRegistryFileEntries(inFile, types, options)...)
+	}
+	entries = append(entries, fg.anyRegistryWellKnownEntries()...)
+
+	err := resolveAnyRegistryVariants(entries, options)
+	if err != nil {
+		return nil, err
+	}
+
+	fg.GenerateModule(options.AnyRegistry)
+	fg.P("")
+	fg.P("-- DO NOT EDIT")
+	fg.P("-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER")
+	fg.P("-- https://github.com/tiziano88/elm-protobuf")
+
+	fg.GenerateBaseImports()
+	if options.Bytes == bytesElmBytes {
+		fg.P("import Bytes")
+	}
+	for _, inFile := range inFiles {
+		if len(inFile.GetMessageType()) > 0 {
+			fg.P("import %s", elmModuleName(inFile, options))
+		}
+	}
+	for _, module := range anyRegistryMappedModules(entries) {
+		fg.P("import %s", module)
+	}
+
+	fg.P("")
+	fg.P("")
+	fg.P("type Message")
+	{
+		fg.In()
+		leading := "="
+		for _, e := range entries {
+			fg.P("%s %s %s", leading, e.variant, e.elmType)
+			leading = "|"
+		}
+		fg.P("%s Unknown Any", leading)
+		fg.Out()
+	}
+
+	fg.P("")
+	fg.P("")
+	fg.P("unpack : Any -> Result JD.Error Message")
+	fg.P("unpack v =")
+	{
+		fg.In()
+		fg.P("case anyTypeName v of")
+		{
+			fg.In()
+			for _, e := range entries {
+				unpack := "anyUnpack"
+				if e.wellKnown {
+					unpack = "anyUnpackWellKnown"
+				}
+				fg.P("%q ->", e.protoName)
+				fg.In()
+				fg.P("Result.map %s <| %s %s v", e.variant, unpack, e.decoder)
+				fg.Out()
+				fg.P("")
+			}
+			fg.P("_ ->")
+			fg.In()
+			fg.P("Ok <| Unknown v")
+			fg.Out()
+			fg.Out()
+		}
+		fg.Out()
+	}
+
+	fg.P("")
+	fg.P("")
+	fg.P("pack : Message -> Any")
+	fg.P("pack message =")
+	{
+		fg.In()
+		fg.P("case message of")
+		{
+			fg.In()
+			for _, e := range entries {
+				pack := "anyPack"
+				if e.wellKnown {
+					pack = "anyPackWellKnown"
+				}
+				fg.P("%s v ->", e.variant)
+				fg.In()
+				fg.P("%s %q %s v", pack, e.protoName, e.encoder)
+				fg.Out()
+				fg.P("")
+			}
+			fg.P("Unknown v ->")
+			fg.In()
+			fg.P("v")
+			fg.Out()
+			fg.Out()
+		}
+		fg.Out()
+	}
+
+	fg.P("")
+	fg.P("")
+	fg.P("messageDecoder : JD.Decoder Message")
+	fg.P("messageDecoder =")
+	fg.In()
+	fg.P("anyDecoder |> JD.andThen (unpack >> Result.mapError JD.errorToString >> fromResult)")
+	fg.Out()
+
+	fg.P("")
+	fg.P("")
+	fg.P("messageEncoder : Message -> JE.Value")
+	fg.P("messageEncoder message =")
+	fg.In()
+	fg.P("anyEncoder <| pack message")
+	fg.Out()
+
+	return &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(strings.Replace(options.AnyRegistry, ".", "/", -1) + ".elm"),
+		Content: proto.String(b.String()),
+	}, nil
+}
+
+// resolveAnyRegistryVariants renames or reports the variants of the registry union that collide,
+// e.g. `A_B_C` for both `a.B_C` and `a.b.C`, according to the `name_collisions` option.
+func resolveAnyRegistryVariants(entries []anyRegistryEntry, options *Options) error {
+	constructors := newNamespace()
+	var symbols []*symbol
+	for i := range entries {
+		e := &entries[i]
+		symbols = append(symbols, &symbol{
+			desc:       "message " + e.protoName,
+			name:       e.variant,
+			namespaces: []*namespace{constructors},
+			assign:     func(n string) { e.variant = n },
+		})
+	}
+	for _, s := range symbols {
+		s.request(1)
+	}
+	return resolveSymbols(symbols, options, func(format string, a ...interface{}) error {
+		return fmt.Errorf("%s: %s", options.AnyRegistry, fmt.Sprintf(format, a...))
+	})
+}
+
+// anyRegistryMappedModules returns the modules of the type mappings used by the Well Known Types in
+// the registry.
+func anyRegistryMappedModules(entries []anyRegistryEntry) []string {
+	modules := map[string]bool{}
+	for _, e := range entries {
+		if e.mappedModule != "" {
+			modules[e.mappedModule] = true
+		}
+	}
+
+	out := []string{}
+	for m := range modules {
+		out = append(out, m)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func anyRegistryFileEntries(inFile *descriptor.FileDescriptorProto, types typeIndex, options *Options) []anyRegistryEntry {
+	var entries []anyRegistryEntry
+	protoPrefix := ""
+	if inFile.GetPackage() != "" {
+		protoPrefix = inFile.GetPackage() + "."
+	}
+	for _, inMessage := range inFile.GetMessageType() {
+		entries = appendAnyRegistryMessage(entries, types, elmModuleName(inFile, options), protoPrefix, inMessage)
+	}
+	return entries
+}
+
+func appendAnyRegistryMessage(entries []anyRegistryEntry, types typeIndex, moduleName, protoPrefix string, inMessage *descriptor.DescriptorProto) []anyRegistryEntry {
+	if inMessage.GetOptions().GetMapEntry() {
+		return entries
+	}
+
+	typeName := types.names.messages[inMessage]
+	protoName := protoPrefix + inMessage.GetName()
+	entries = append(entries, anyRegistryEntry{
+		protoName: protoName,
+		variant:   anyRegistryVariant(protoName),
+		elmType:   moduleName + "." + typeName,
+		decoder:   moduleName + "." + decoderName(typeName),
+		encoder:   moduleName + "." + encoderName(typeName),
+	})
+
+	for _, nested := range inMessage.GetNestedType() {
+		entries = appendAnyRegistryMessage(entries, types, moduleName, protoName+".", nested)
+	}
+	return entries
+}
+
+func (fg *FileGenerator) anyRegistryWellKnownEntries() []anyRegistryEntry {
+	names := []string{}
+	for typeName := range excludedTypes {
+		// Enums cannot be packed in an Any.
+		if _, ok := excludedDefaults[typeName]; ok {
+			continue
+		}
+		names = append(names, typeName)
+	}
+	sort.Strings(names)
+
+	var entries []anyRegistryEntry
+	for _, typeName := range names {
+		t, _ := fg.wellKnownType(typeName)
+		protoName := strings.TrimPrefix(typeName, ".")
+		entries = append(entries, anyRegistryEntry{
+			protoName:    protoName,
+			variant:      anyRegistryVariant(protoName),
+			elmType:      t.elmType,
+			decoder:      t.decoder,
+			encoder:      t.encoder,
+			wellKnown:    !inlinedWellKnownTypes[typeName],
+			mappedModule: fg.options.TypeMappings[typeName].Module,
+		})
+	}
+	return entries
+}
+
+// anyRegistryVariant returns the name of the union variant for a message, e.g. `Foo_Bar_Baz` for
+// `foo.bar.Baz`, avoiding the `Unknown` variant and reserved names.
+func anyRegistryVariant(protoName string) string {
+	segments := strings.Split(protoName, ".")
+	for i, s := range segments {
+		segments[i] = firstUpper(s)
+	}
+	variant := strings.Join(segments, "_")
+	if variant == "Unknown" {
+		return variant + "_"
+	}
+	return avoidReservedTypeName(variant)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// typeMapping maps a proto type to an existing Elm type, decoder and encoder, defined in a
+// hand-written module, which is imported by the generated modules that use it.
+//
+// Type mappings are loaded from a JSON file passed via the `type_mappings` option, keyed by the
+// fully-qualified name of the proto type:
+//
+//	{
+//	  "our.money.Money": {
+//	    "module": "Our.Money",
+//	    "type": "Money",
+//	    "decoder": "moneyDecoder",
+//	    "encoder": "moneyEncoder"
+//	  }
+//	}
+type typeMapping struct {
+	Module  string `json:"module"`
+	Type    string `json:"type"`
+	Decoder string `json:"decoder"`
+	Encoder string `json:"encoder"`
+	// Value used when an enum field is missing; required for enum types.
+	Default string `json:"default"`
+}
+
+// loadTypeMappings reads the type mappings from the given JSON file, and returns them keyed by the
+// fully-qualified type name, including the leading dot.
+func loadTypeMappings(path string) (map[string]typeMapping, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var in map[string]typeMapping
+	err = json.Unmarshal(data, &in)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %v", path, err)
+	}
+
+	out := map[string]typeMapping{}
+	for typeName, m := range in {
+		err := m.validate()
+		if err != nil {
+			return nil, fmt.Errorf("%s: type %s: %v", path, typeName, err)
+		}
+		out["."+strings.TrimPrefix(typeName, ".")] = m
+	}
+	return out, nil
+}
+
+func (m typeMapping) validate() error {
+	if !isElmModuleName(m.Module) {
+		return fmt.Errorf("`module` must be an Elm module name, got %q", m.Module)
+	}
+	if !isElmTypeIdentifier(m.Type) {
+		return fmt.Errorf("`type` must be an Elm type name, got %q", m.Type)
+	}
+	if !isElmValueIdentifier(m.Decoder) {
+		return fmt.Errorf("`decoder` must be an Elm function name, got %q", m.Decoder)
+	}
+	if !isElmValueIdentifier(m.Encoder) {
+		return fmt.Errorf("`encoder` must be an Elm function name, got %q", m.Encoder)
+	}
+	if m.Default != "" && !isElmTypeIdentifier(m.Default) && !isElmValueIdentifier(m.Default) {
+		return fmt.Errorf("`default` must be an Elm value or constructor name, got %q", m.Default)
+	}
+	return nil
+}
+
+// typeMapping returns the Elm representation of a type mapped by the `type_mappings` option, with
+// all names qualified by the module that defines them.
+func (fg *FileGenerator) typeMapping(typeName string) (wellKnownType, bool) {
+	m, ok := fg.options.TypeMappings[typeName]
+	if !ok {
+		return wellKnownType{}, false
+	}
+	return wellKnownType{
+		elmType: m.Module + "." + m.Type,
+		decoder: m.Module + "." + m.Decoder,
+		encoder: m.Module + "." + m.Encoder,
+	}, true
+}
+
+// enumDefault returns the default value of an enum that is a Well Known Type or mapped by the
+// `type_mappings` option.
+func (fg *FileGenerator) enumDefault(typeName string) (string, bool) {
+	if m, ok := fg.options.TypeMappings[typeName]; ok {
+		return m.Module + "." + m.Default, true
+	}
+	def, ok := excludedDefaults[typeName]
+	return def, ok
+}
+
+// typeMappingModules returns the modules of the type mappings used by the fields of the file.
+func (fg *FileGenerator) typeMappingModules(inFile *descriptor.FileDescriptorProto) []string {
+	modules := map[string]bool{}
+	anyField(inFile, func(inField *descriptor.FieldDescriptorProto) bool {
+		if m, ok := fg.options.TypeMappings[inField.GetTypeName()]; ok {
+			modules[m.Module] = true
+		}
+		return false
+	})
+
+	out := []string{}
+	for m := range modules {
+		out = append(out, m)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// isMappedFile returns whether all the types defined in the given file, including nested ones, are
+// mapped by the `type_mappings` option, in which case the file does not need to be imported.
+func (fg *FileGenerator) isMappedFile(inFileName string) bool {
+	mapped := false
+	for typeName, t := range fg.types.byName {
+		if t.file.GetName() != inFileName {
+			continue
+		}
+		if t.message.GetOptions().GetMapEntry() {
+			// Map entries are generated as `Dict`s rather than types.
+			continue
+		}
+		if _, ok := fg.options.TypeMappings[typeName]; !ok {
+			return false
+		}
+		mapped = true
+	}
+	return mapped
+}
@@ -0,0 +1,90 @@
+package main
+
+import "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+// typeIndex holds the definitions of all the files in the request, including dependencies.
+type typeIndex struct {
+	// Maps the fully-qualified name of each message and enum type (e.g. `.foo.Outer.Inner`) to its
+	// definition.
+	byName map[string]*typeInfo
+	// Maps the name of each file (e.g. `foo/bar.proto`) to its descriptor.
+	files map[string]*descriptor.FileDescriptorProto
+	// Elm names of the elements of the files, filled by elmNames.addFile.
+	names *elmNames
+}
+
+// typeInfo describes a message or enum type; exactly one of message and enum is set.
+type typeInfo struct {
+	// File in which the type is defined.
+	file    *descriptor.FileDescriptorProto
+	message *descriptor.DescriptorProto
+	enum    *descriptor.EnumDescriptorProto
+}
+
+// newTypeIndex indexes all the types defined in the given files, including nested ones.
+func newTypeIndex(inFiles []*descriptor.FileDescriptorProto) typeIndex {
+	types := typeIndex{
+		byName: map[string]*typeInfo{},
+		files:  map[string]*descriptor.FileDescriptorProto{},
+		names:  newElmNames(),
+	}
+	for _, inFile := range inFiles {
+		types.files[inFile.GetName()] = inFile
+		prefix := "."
+		if inFile.GetPackage() != "" {
+			prefix += inFile.GetPackage() + "."
+		}
+		for _, inEnum := range inFile.GetEnumType() {
+			types.byName[prefix+inEnum.GetName()] = &typeInfo{file: inFile, enum: inEnum}
+		}
+		for _, inMessage := range inFile.GetMessageType() {
+			types.addMessage(inFile, prefix, inMessage)
+		}
+	}
+	return types
+}
+
+func (types typeIndex) addMessage(inFile *descriptor.FileDescriptorProto, prefix string, inMessage *descriptor.DescriptorProto) {
+	name := prefix + inMessage.GetName()
+	types.byName[name] = &typeInfo{file: inFile, message: inMessage}
+	for _, inEnum := range inMessage.GetEnumType() {
+		types.byName[name+"."+inEnum.GetName()] = &typeInfo{file: inFile, enum: inEnum}
+	}
+	for _, nested := range inMessage.GetNestedType() {
+		types.addMessage(inFile, name+".", nested)
+	}
+}
+
+// message returns the definition of the message type referenced by a field, or nil if the field
+// does not have a message type or the type is unknown.
+func (types typeIndex) message(inField *descriptor.FieldDescriptorProto) *descriptor.DescriptorProto {
+	if t, ok := types.byName[inField.GetTypeName()]; ok {
+		return t.message
+	}
+	return nil
+}
+
+// anyField returns whether any field of the messages in the file, including nested ones, satisfies
+// the predicate.
+func anyField(inFile *descriptor.FileDescriptorProto, pred func(*descriptor.FieldDescriptorProto) bool) bool {
+	for _, inMessage := range inFile.GetMessageType() {
+		if anyFieldInMessage(inMessage, pred) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyFieldInMessage(inMessage *descriptor.DescriptorProto, pred func(*descriptor.FieldDescriptorProto) bool) bool {
+	for _, inField := range inMessage.GetField() {
+		if pred(inField) {
+			return true
+		}
+	}
+	for _, nested := range inMessage.GetNestedType() {
+		if anyFieldInMessage(nested, pred) {
+			return true
+		}
+	}
+	return false
+}
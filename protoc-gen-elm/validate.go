@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// errorList collects several errors, so that they can all be reported at once.
+type errorList []error
+
+func (l errorList) Error() string {
+	s := make([]string, 0, len(l))
+	for _, err := range l {
+		s = append(s, err.Error())
+	}
+	return strings.Join(s, "\n")
+}
+
+func fileErrorf(inFile *descriptor.FileDescriptorProto, format string, a ...interface{}) error {
+	return fmt.Errorf("%s: %s", inFile.GetName(), fmt.Sprintf(format, a...))
+}
+
+func messageErrorf(inFile *descriptor.FileDescriptorProto, messageName string, format string, a ...interface{}) error {
+	return fileErrorf(inFile, "message %s: %s", messageName, fmt.Sprintf(format, a...))
+}
+
+func fieldErrorf(inFile *descriptor.FileDescriptorProto, messageName string, inField *descriptor.FieldDescriptorProto, format string, a ...interface{}) error {
+	return fileErrorf(inFile, "message %s, field %s: %s", messageName, inField.GetName(), fmt.Sprintf(format, a...))
+}
+
+// validateFile checks that the file only uses features supported by the generator, and returns
+// all the problems found, each naming the file, message and field at fault.
+func validateFile(inFile *descriptor.FileDescriptorProto, options *Options) errorList {
+	var errs errorList
+
+	switch syntax := fileSyntax(inFile); syntax {
+	case "proto2", "proto3":
+	case "editions":
+		edition := inFile.GetEdition()
+		if edition < minimumEdition || edition > maximumEdition {
+			errs = append(errs, fileErrorf(inFile, "unsupported edition %s, the latest supported edition is %s", edition, maximumEdition))
+			return errs
+		}
+	default:
+		errs = append(errs, fileErrorf(inFile, "unsupported syntax %q", syntax))
+		return errs
+	}
+
+	prefix := strings.TrimPrefix(inFile.GetPackage()+".", ".")
+	for _, inMessage := range inFile.GetMessageType() {
+		errs = append(errs, validateMessage(inFile, options, prefix+inMessage.GetName(), inMessage)...)
+	}
+
+	return errs
+}
+
+func validateMessage(inFile *descriptor.FileDescriptorProto, options *Options, messageName string, inMessage *descriptor.DescriptorProto) errorList {
+	var errs errorList
+
+	if inMessage.GetOptions().GetMapEntry() {
+		errs = append(errs, validateMapEntry(inFile, messageName, inMessage)...)
+	}
+
+	for _, inField := range inMessage.GetField() {
+		m, ok := options.TypeMappings[inField.GetTypeName()]
+		if ok && inField.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM && m.Default == "" {
+			errs = append(errs, fieldErrorf(inFile, messageName, inField, "type mapping for enum %s must have a `default`", inField.GetTypeName()))
+		}
+	}
+
+	for _, nested := range inMessage.GetNestedType() {
+		errs = append(errs, validateMessage(inFile, options, messageName+"."+nested.GetName(), nested)...)
+	}
+
+	return errs
+}
+
+func validateMapEntry(inFile *descriptor.FileDescriptorProto, messageName string, inMessage *descriptor.DescriptorProto) errorList {
+	var errs errorList
+
+	if len(inMessage.GetField()) != 2 {
+		errs = append(errs, messageErrorf(inFile, messageName, "map entry must have exactly two fields, got %d", len(inMessage.GetField())))
+		return errs
+	}
+
+	keyField := inMessage.GetField()[0]
+	if keyField.GetName() != "key" {
+		errs = append(errs, fieldErrorf(inFile, messageName, keyField, "first map entry field must be called `key`"))
+	}
+	switch keyField.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT,
+		descriptor.FieldDescriptorProto_TYPE_DOUBLE,
+		descriptor.FieldDescriptorProto_TYPE_BYTES,
+		descriptor.FieldDescriptorProto_TYPE_ENUM,
+		descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		errs = append(errs, fieldErrorf(inFile, messageName, keyField, "map key must have an integral, `bool` or `string` type, got %s", keyField.GetType()))
+	}
+
+	valueField := inMessage.GetField()[1]
+	if valueField.GetName() != "value" {
+		errs = append(errs, fieldErrorf(inFile, messageName, valueField, "second map entry field must be called `value`"))
+	}
+
+	return errs
+}
@@ -1,13 +1,28 @@
 module Protobuf exposing
-    ( decode, required, optional, repeated, field
-    , withDefault, intDecoder, fromResult
-    , requiredFieldEncoder, optionalEncoder, repeatedFieldEncoder, numericStringEncoder, mapEntriesFieldEncoder, mapEntries
+    ( decode, required, requiredStrict, optional, repeated, field
+    , withDefault, intDecoder, floatDecoder, fromResult
+    , requiredFieldEncoder, requiredStrictFieldEncoder, optionalEncoder, repeatedFieldEncoder, numericStringEncoder, floatEncoder
+    , mapEntries, mapEntriesFieldEncoder, keyedMapEntries, keyedMapEntriesFieldEncoder
+    , BoolDict, emptyBoolDict, boolMapEntries, boolMapEntriesFieldEncoder
+    , enumNameOrNumberDecoder, enumNameOrNumberEncoder
+    , Int64, int64Decoder, int64Encoder, int64Zero, int64FromString, int64ToString, int64FromInt
+    , UInt64, uint64Decoder, uint64Encoder, uint64Zero, uint64FromString, uint64ToString, uint64FromInt
     , Bytes, bytesFieldDecoder, bytesFieldEncoder
+    , elmBytesFieldDecoder, elmBytesFieldEncoder, requiredElmBytesFieldEncoder, emptyElmBytes, elmBytesFromList, elmBytesToList
     , Timestamp, timestampDecoder, timestampEncoder
+    , Duration, durationDecoder, durationEncoder
+    , FieldMask, fieldMaskDecoder, fieldMaskEncoder
+    , unitDecoder, unitEncoder
+    , Any, anyDecoder, anyEncoder, anyTypeName, anyUnpack, anyPack, anyUnpackWellKnown, anyPackWellKnown
+    , Value(..), valueDecoder, valueEncoder
+    , Struct, structDecoder, structEncoder
+    , ListValue, listValueDecoder, listValueEncoder
+    , NullValue, nullValueDecoder, nullValueEncoder
     , intValueDecoder, intValueEncoder
     , stringValueDecoder, stringValueEncoder
     , boolValueDecoder, boolValueEncoder
     , bytesValueDecoder, bytesValueEncoder
+    , elmBytesValueDecoder, elmBytesValueEncoder
     , floatValueDecoder, floatValueEncoder
     )
 
@@ -19,25 +34,79 @@ Buffer compiler](https://github.com/tiziano88/elm-protobuf).
 
 # Decoder Helpers
 
-@docs decode, required, optional, repeated, field
+@docs decode, required, requiredStrict, optional, repeated, field
 
-@docs withDefault, intDecoder, fromResult
+@docs withDefault, intDecoder, floatDecoder, fromResult
 
 
 # Encoder Helpers
 
-@docs requiredFieldEncoder, optionalEncoder, repeatedFieldEncoder, numericStringEncoder
+@docs requiredFieldEncoder, requiredStrictFieldEncoder, optionalEncoder, repeatedFieldEncoder, numericStringEncoder, floatEncoder
+
+
+# Maps
+
+Map keys are always strings in JSON. Integer keys are converted to and from `Int`, and bool keys,
+which cannot be used in a `Dict`, are stored in a `BoolDict` instead.
+
+@docs mapEntries, mapEntriesFieldEncoder, keyedMapEntries, keyedMapEntriesFieldEncoder
+
+@docs BoolDict, emptyBoolDict, boolMapEntries, boolMapEntriesFieldEncoder
+
+
+# Enums
+
+When generating code with the `unknown_enum_values=preserve` or `unknown_enum_values=fail` option,
+enum values are decoded from either their name or their number. With `preserve`, values that are
+not known to the generated code are kept as a string, so that they can be encoded back unchanged.
+
+@docs enumNameOrNumberDecoder, enumNameOrNumberEncoder
+
+
+# 64-bit Integers
+
+Elm `Int` values lose precision above 2^53, so when generating code with the `int64=string` option,
+64-bit integer fields use the following types instead, which store the canonical decimal
+representation of the value and cover the full range of `int64` and `uint64`.
+
+@docs Int64, int64Decoder, int64Encoder, int64Zero, int64FromString, int64ToString, int64FromInt
+
+@docs UInt64, uint64Decoder, uint64Encoder, uint64Zero, uint64FromString, uint64ToString, uint64FromInt
 
 
 # Bytes
 
+Bytes are encoded as standard base64 strings, and may be decoded from either standard or URL-safe
+base64, with or without padding.
+
 @docs Bytes, bytesFieldDecoder, bytesFieldEncoder
 
+When generating code with the `bytes=elm_bytes` option, bytes fields use `Bytes.Bytes` from
+[elm/bytes](https://package.elm-lang.org/packages/elm/bytes/latest/) instead.
+
+@docs elmBytesFieldDecoder, elmBytesFieldEncoder, requiredElmBytesFieldEncoder, emptyElmBytes, elmBytesFromList, elmBytesToList
+
 
 # Well Known Types
 
 @docs Timestamp, timestampDecoder, timestampEncoder
 
+@docs Duration, durationDecoder, durationEncoder
+
+@docs FieldMask, fieldMaskDecoder, fieldMaskEncoder
+
+@docs unitDecoder, unitEncoder
+
+@docs Any, anyDecoder, anyEncoder, anyTypeName, anyUnpack, anyPack, anyUnpackWellKnown, anyPackWellKnown
+
+@docs Value, valueDecoder, valueEncoder
+
+@docs Struct, structDecoder, structEncoder
+
+@docs ListValue, listValueDecoder, listValueEncoder
+
+@docs NullValue, nullValueDecoder, nullValueEncoder
+
 @docs intValueDecoder, intValueEncoder
 
 @docs stringValueDecoder, stringValueEncoder
@@ -46,10 +115,16 @@ Buffer compiler](https://github.com/tiziano88/elm-protobuf).
 
 @docs bytesValueDecoder, bytesValueEncoder
 
+@docs elmBytesValueDecoder, elmBytesValueEncoder
+
 @docs floatValueDecoder, floatValueEncoder
 
 -}
 
+import Bitwise
+import Bytes
+import Bytes.Decode
+import Bytes.Encode
 import ISO8601
 import Json.Decode as JD
 import Json.Encode as JE
@@ -68,27 +143,34 @@ decode =
 -}
 required : String -> JD.Decoder a -> a -> JD.Decoder (a -> b) -> JD.Decoder b
 required name decoder default d =
-    field (withDefault default <| JD.field name decoder) d
+    field (fieldWithDefault name default decoder) d
+
+
+{-| Decodes a proto2 required field, failing if it is missing.
+-}
+requiredStrict : String -> JD.Decoder a -> JD.Decoder (a -> b) -> JD.Decoder b
+requiredStrict name decoder d =
+    field (JD.field name decoder) d
 
 
 {-| Decodes an optional field.
 -}
 optional : String -> JD.Decoder a -> JD.Decoder (Maybe a -> b) -> JD.Decoder b
 optional name decoder d =
-    field (JD.maybe <| JD.field name decoder) d
+    field (fieldWithDefault name Nothing <| JD.map Just decoder) d
 
 
 {-| Decodes a repeated field.
 -}
 repeated : String -> JD.Decoder a -> JD.Decoder (List a -> b) -> JD.Decoder b
 repeated name decoder d =
-    field (withDefault [] <| JD.field name <| JD.list decoder) d
+    field (fieldWithDefault name [] <| JD.list decoder) d
 
 {-| Decodes a Dict.
 -}
 mapEntries : String -> JD.Decoder a -> JD.Decoder (Dict.Dict String a -> b) -> JD.Decoder b
 mapEntries name valueDecoder d =
-    field (withDefault Dict.empty <| JD.field name <| JD.dict valueDecoder) d
+    field (fieldWithDefault name Dict.empty <| JD.dict valueDecoder) d
 
 
 {-| Decodes a field.
@@ -98,6 +180,24 @@ field =
     JD.map2 (|>)
 
 
+{-| Decodes the named field, using the default value if the field is missing or null. Unlike
+`withDefault`, values that the decoder rejects (e.g. unknown enum values) fail the whole decoder.
+-}
+fieldWithDefault : String -> a -> JD.Decoder a -> JD.Decoder a
+fieldWithDefault name default decoder =
+    JD.maybe (JD.field name JD.value)
+        |> JD.andThen
+            (\value ->
+                case value of
+                    Nothing ->
+                        JD.succeed default
+
+                    Just _ ->
+                        -- Some decoders accept null, e.g. `valueDecoder`.
+                        JD.field name <| JD.oneOf [ decoder, JD.null default ]
+            )
+
+
 {-| Provides a default value for a field.
 -}
 withDefault : a -> JD.Decoder a -> JD.Decoder a
@@ -131,6 +231,13 @@ requiredFieldEncoder name encoder default v =
         Just ( name, encoder v )
 
 
+{-| Encodes a proto2 required field, which is always present.
+-}
+requiredStrictFieldEncoder : String -> (a -> JE.Value) -> a -> Maybe ( String, JE.Value )
+requiredStrictFieldEncoder name encoder v =
+    Just ( name, encoder v )
+
+
 {-| Encodes a repeated field.
 -}
 repeatedFieldEncoder : String -> (a -> JE.Value) -> List a -> Maybe ( String, JE.Value )
@@ -156,6 +263,278 @@ mapEntriesFieldEncoder name valueEncoder v =
             Just ( name, JE.object encodedItems)
 
 
+{-| Decodes a map field whose keys are converted from strings with the given function, failing if
+any key cannot be converted.
+-}
+keyedMapEntries : String -> (String -> Maybe comparable) -> JD.Decoder a -> JD.Decoder (Dict.Dict comparable a -> b) -> JD.Decoder b
+keyedMapEntries name keyFromString valueDecoder d =
+    field (fieldWithDefault name Dict.empty <| keyedDict keyFromString valueDecoder) d
+
+
+keyedDict : (String -> Maybe comparable) -> JD.Decoder a -> JD.Decoder (Dict.Dict comparable a)
+keyedDict keyFromString valueDecoder =
+    let
+        convertKey ( key, value ) =
+            case keyFromString key of
+                Just k ->
+                    JD.succeed ( k, value )
+
+                Nothing ->
+                    JD.fail <| "invalid map key: " ++ key
+    in
+    JD.keyValuePairs valueDecoder
+        |> JD.andThen (List.foldr (convertKey >> JD.map2 (::)) (JD.succeed []))
+        |> JD.map Dict.fromList
+
+
+{-| Encodes a map field whose keys are converted to strings with the given function.
+-}
+keyedMapEntriesFieldEncoder : String -> (comparable -> String) -> (a -> JE.Value) -> Dict.Dict comparable a -> Maybe ( String, JE.Value )
+keyedMapEntriesFieldEncoder name keyToString valueEncoder v =
+    Dict.toList v
+        |> List.map (Tuple.mapFirst keyToString)
+        |> Dict.fromList
+        |> mapEntriesFieldEncoder name valueEncoder
+
+
+{-| Map with bool keys.
+-}
+type alias BoolDict a =
+    { whenTrue : Maybe a
+    , whenFalse : Maybe a
+    }
+
+
+{-| Map with bool keys and no entries.
+-}
+emptyBoolDict : BoolDict a
+emptyBoolDict =
+    { whenTrue = Nothing
+    , whenFalse = Nothing
+    }
+
+
+{-| Decodes a map field with bool keys.
+-}
+boolMapEntries : String -> JD.Decoder a -> JD.Decoder (BoolDict a -> b) -> JD.Decoder b
+boolMapEntries name valueDecoder d =
+    let
+        boolKey key =
+            if key == "true" || key == "false" then
+                Just key
+
+            else
+                Nothing
+
+        toBoolDict dict =
+            { whenTrue = Dict.get "true" dict
+            , whenFalse = Dict.get "false" dict
+            }
+    in
+    field (fieldWithDefault name emptyBoolDict <| JD.map toBoolDict <| keyedDict boolKey valueDecoder) d
+
+
+{-| Encodes a map field with bool keys.
+-}
+boolMapEntriesFieldEncoder : String -> (a -> JE.Value) -> BoolDict a -> Maybe ( String, JE.Value )
+boolMapEntriesFieldEncoder name valueEncoder v =
+    [ Maybe.map (Tuple.pair "true") v.whenTrue
+    , Maybe.map (Tuple.pair "false") v.whenFalse
+    ]
+        |> List.filterMap identity
+        |> Dict.fromList
+        |> mapEntriesFieldEncoder name valueEncoder
+
+
+{-| Decodes an enum value from either its name or its number, returning the number as a decimal
+string.
+-}
+enumNameOrNumberDecoder : JD.Decoder String
+enumNameOrNumberDecoder =
+    JD.oneOf [ JD.string, JD.map String.fromInt JD.int ]
+
+
+{-| Encodes an enum value, given either its name or its number as a decimal string.
+-}
+enumNameOrNumberEncoder : String -> JE.Value
+enumNameOrNumberEncoder v =
+    case String.toInt v of
+        Just n ->
+            JE.int n
+
+        Nothing ->
+            JE.string v
+
+
+{-| Signed 64-bit integer, used for `int64`, `sint64` and `sfixed64` fields.
+-}
+type Int64
+    = Int64 String
+
+
+{-| Unsigned 64-bit integer, used for `uint64` and `fixed64` fields.
+-}
+type UInt64
+    = UInt64 String
+
+
+{-| Decodes an Int64 from either a string or numeric.
+-}
+int64Decoder : JD.Decoder Int64
+int64Decoder =
+    integerStringDecoder
+        |> JD.andThen (int64FromString >> fromMaybe "could not convert string to int64")
+
+
+{-| Encodes an Int64 as a JSON string.
+-}
+int64Encoder : Int64 -> JE.Value
+int64Encoder v =
+    JE.string <| int64ToString v
+
+
+{-| Default value of Int64 fields.
+-}
+int64Zero : Int64
+int64Zero =
+    Int64 "0"
+
+
+{-| Parses a decimal string, returning `Nothing` if it is not a valid signed 64-bit integer.
+-}
+int64FromString : String -> Maybe Int64
+int64FromString s =
+    case String.uncons s of
+        Just ( '-', digits ) ->
+            canonicalDigits digits
+                |> Maybe.andThen
+                    (\d ->
+                        if d == "0" then
+                            Just int64Zero
+
+                        else if digitsAtMost "9223372036854775808" d then
+                            Just <| Int64 <| "-" ++ d
+
+                        else
+                            Nothing
+                    )
+
+        _ ->
+            canonicalDigits s
+                |> Maybe.andThen
+                    (\d ->
+                        if digitsAtMost "9223372036854775807" d then
+                            Just <| Int64 d
+
+                        else
+                            Nothing
+                    )
+
+
+{-| Returns the decimal representation of an Int64.
+-}
+int64ToString : Int64 -> String
+int64ToString (Int64 v) =
+    v
+
+
+{-| Converts an Int to an Int64. This is only exact for values that an Int can represent precisely.
+-}
+int64FromInt : Int -> Int64
+int64FromInt v =
+    int64FromString (String.fromInt v)
+        |> Maybe.withDefault int64Zero
+
+
+{-| Decodes a UInt64 from either a string or numeric.
+-}
+uint64Decoder : JD.Decoder UInt64
+uint64Decoder =
+    integerStringDecoder
+        |> JD.andThen (uint64FromString >> fromMaybe "could not convert string to uint64")
+
+
+{-| Encodes a UInt64 as a JSON string.
+-}
+uint64Encoder : UInt64 -> JE.Value
+uint64Encoder v =
+    JE.string <| uint64ToString v
+
+
+{-| Default value of UInt64 fields.
+-}
+uint64Zero : UInt64
+uint64Zero =
+    UInt64 "0"
+
+
+{-| Parses a decimal string, returning `Nothing` if it is not a valid unsigned 64-bit integer.
+-}
+uint64FromString : String -> Maybe UInt64
+uint64FromString s =
+    canonicalDigits s
+        |> Maybe.andThen
+            (\d ->
+                if digitsAtMost "18446744073709551615" d then
+                    Just <| UInt64 d
+
+                else
+                    Nothing
+            )
+
+
+{-| Returns the decimal representation of a UInt64.
+-}
+uint64ToString : UInt64 -> String
+uint64ToString (UInt64 v) =
+    v
+
+
+{-| Converts an Int to a UInt64, returning `Nothing` for negative values. This is only exact for
+values that an Int can represent precisely.
+-}
+uint64FromInt : Int -> Maybe UInt64
+uint64FromInt v =
+    uint64FromString (String.fromInt v)
+
+
+integerStringDecoder : JD.Decoder String
+integerStringDecoder =
+    JD.oneOf [ JD.string, JD.map String.fromInt JD.int ]
+
+
+{-| Validates a non-empty string of decimal digits, and strips its leading zeros.
+-}
+canonicalDigits : String -> Maybe String
+canonicalDigits s =
+    if String.isEmpty s || not (String.all Char.isDigit s) then
+        Nothing
+
+    else
+        case dropLeadingZeros s of
+            "" ->
+                Just "0"
+
+            d ->
+                Just d
+
+
+dropLeadingZeros : String -> String
+dropLeadingZeros s =
+    if String.startsWith "0" s then
+        dropLeadingZeros (String.dropLeft 1 s)
+
+    else
+        s
+
+
+{-| Compares two canonical strings of decimal digits.
+-}
+digitsAtMost : String -> String -> Bool
+digitsAtMost max d =
+    String.length d < String.length max || (String.length d == String.length max && d <= max)
+
+
 {-| Bytes field.
 -}
 type alias Bytes =
@@ -163,19 +542,215 @@ type alias Bytes =
 
 
 {-| Decodes a bytes field.
-TODO: Implement.
 -}
 bytesFieldDecoder : JD.Decoder Bytes
 bytesFieldDecoder =
-    JD.succeed []
+    JD.string
+        |> JD.andThen (base64Decode >> fromMaybe "could not decode base64 string")
 
 
 {-| Encodes a bytes field.
-TODO: Implement.
 -}
 bytesFieldEncoder : Bytes -> JE.Value
 bytesFieldEncoder v =
-    JE.list JE.int []
+    JE.string <| base64Encode v
+
+
+{-| Decodes a bytes field as `Bytes.Bytes`.
+-}
+elmBytesFieldDecoder : JD.Decoder Bytes.Bytes
+elmBytesFieldDecoder =
+    JD.map elmBytesFromList bytesFieldDecoder
+
+
+{-| Encodes a `Bytes.Bytes` field.
+-}
+elmBytesFieldEncoder : Bytes.Bytes -> JE.Value
+elmBytesFieldEncoder v =
+    bytesFieldEncoder <| elmBytesToList v
+
+
+{-| Encodes a `Bytes.Bytes` field, omitting it if it has its default value.
+
+This is the same as `requiredFieldEncoder`, but compares the contents of the values, since `Bytes.Bytes`
+values cannot be compared with `==`.
+
+-}
+requiredElmBytesFieldEncoder : String -> (Bytes.Bytes -> JE.Value) -> Bytes.Bytes -> Bytes.Bytes -> Maybe ( String, JE.Value )
+requiredElmBytesFieldEncoder name encoder default v =
+    if elmBytesToList v == elmBytesToList default then
+        Nothing
+
+    else
+        Just ( name, encoder v )
+
+
+{-| Empty `Bytes.Bytes`, the default value of bytes fields.
+-}
+emptyElmBytes : Bytes.Bytes
+emptyElmBytes =
+    elmBytesFromList []
+
+
+{-| Converts a list of bytes to `Bytes.Bytes`.
+-}
+elmBytesFromList : Bytes -> Bytes.Bytes
+elmBytesFromList v =
+    Bytes.Encode.encode <| Bytes.Encode.sequence <| List.map Bytes.Encode.unsignedInt8 v
+
+
+{-| Converts `Bytes.Bytes` to a list of bytes.
+-}
+elmBytesToList : Bytes.Bytes -> Bytes
+elmBytesToList v =
+    Bytes.Decode.decode (Bytes.Decode.loop ( Bytes.width v, [] ) elmBytesToListStep) v
+        |> Maybe.withDefault []
+
+
+elmBytesToListStep : ( Int, List Int ) -> Bytes.Decode.Decoder (Bytes.Decode.Step ( Int, List Int ) (List Int))
+elmBytesToListStep ( remaining, acc ) =
+    if remaining <= 0 then
+        Bytes.Decode.succeed <| Bytes.Decode.Done <| List.reverse acc
+
+    else
+        Bytes.Decode.map (\x -> Bytes.Decode.Loop ( remaining - 1, x :: acc )) Bytes.Decode.unsignedInt8
+
+
+
+-- Base64.
+
+
+base64Encode : List Int -> String
+base64Encode =
+    base64EncodeHelp []
+
+
+base64EncodeHelp : List String -> List Int -> String
+base64EncodeHelp acc v =
+    case v of
+        a :: b :: c :: rest ->
+            base64EncodeHelp (base64EncodeChunk 4 a b c :: acc) rest
+
+        [ a, b ] ->
+            base64EncodeHelp ((base64EncodeChunk 3 a b 0 ++ "=") :: acc) []
+
+        [ a ] ->
+            base64EncodeHelp ((base64EncodeChunk 2 a 0 0 ++ "==") :: acc) []
+
+        [] ->
+            String.concat <| List.reverse acc
+
+
+{-| Encodes the first `n` sextets of the three given bytes.
+-}
+base64EncodeChunk : Int -> Int -> Int -> Int -> String
+base64EncodeChunk n a b c =
+    let
+        triple =
+            Bitwise.or (Bitwise.shiftLeftBy 16 (Bitwise.and 255 a)) <|
+                Bitwise.or (Bitwise.shiftLeftBy 8 (Bitwise.and 255 b)) (Bitwise.and 255 c)
+
+        sextet i =
+            base64Char <| Bitwise.and 63 <| Bitwise.shiftRightZfBy (18 - 6 * i) triple
+    in
+    String.fromList <| List.map sextet <| List.range 0 (n - 1)
+
+
+base64Char : Int -> Char
+base64Char i =
+    if i < 26 then
+        Char.fromCode (i + 65)
+
+    else if i < 52 then
+        Char.fromCode (i + 71)
+
+    else if i < 62 then
+        Char.fromCode (i - 4)
+
+    else if i == 62 then
+        '+'
+
+    else
+        '/'
+
+
+{-| Decodes standard or URL-safe base64, with or without padding.
+-}
+base64Decode : String -> Maybe (List Int)
+base64Decode s =
+    let
+        unpadded =
+            if String.endsWith "==" s then
+                String.dropRight 2 s
+
+            else if String.endsWith "=" s then
+                String.dropRight 1 s
+
+            else
+                s
+    in
+    String.toList unpadded
+        |> List.foldr (\c acc -> Maybe.map2 (::) (base64Value c) acc) (Just [])
+        |> Maybe.andThen (base64DecodeHelp [])
+
+
+base64DecodeHelp : List Int -> List Int -> Maybe (List Int)
+base64DecodeHelp acc v =
+    case v of
+        a :: b :: c :: d :: rest ->
+            let
+                n =
+                    base64Sextets a b c d
+            in
+            base64DecodeHelp (Bitwise.and 255 n :: Bitwise.and 255 (Bitwise.shiftRightZfBy 8 n) :: Bitwise.shiftRightZfBy 16 n :: acc) rest
+
+        [ a, b, c ] ->
+            let
+                n =
+                    base64Sextets a b c 0
+            in
+            base64DecodeHelp (Bitwise.and 255 (Bitwise.shiftRightZfBy 8 n) :: Bitwise.shiftRightZfBy 16 n :: acc) []
+
+        [ a, b ] ->
+            base64DecodeHelp (Bitwise.shiftRightZfBy 16 (base64Sextets a b 0 0) :: acc) []
+
+        [ _ ] ->
+            Nothing
+
+        [] ->
+            Just <| List.reverse acc
+
+
+base64Sextets : Int -> Int -> Int -> Int -> Int
+base64Sextets a b c d =
+    Bitwise.or (Bitwise.shiftLeftBy 18 a) <|
+        Bitwise.or (Bitwise.shiftLeftBy 12 b) <|
+            Bitwise.or (Bitwise.shiftLeftBy 6 c) d
+
+
+base64Value : Char -> Maybe Int
+base64Value c =
+    let
+        code =
+            Char.toCode c
+    in
+    if code >= 65 && code <= 90 then
+        Just (code - 65)
+
+    else if code >= 97 && code <= 122 then
+        Just (code - 71)
+
+    else if code >= 48 && code <= 57 then
+        Just (code + 4)
+
+    else if c == '+' || c == '-' then
+        Just 62
+
+    else if c == '/' || c == '_' then
+        Just 63
+
+    else
+        Nothing
 
 
 
@@ -211,6 +786,370 @@ timestampEncoder v =
     JE.string <| ISO8601.toString <| ISO8601.fromPosix v
 
 
+{-| Duration, as a number of seconds and nanoseconds. For negative durations, both `seconds` and
+`nanos` are negative (or zero).
+-}
+type alias Duration =
+    { seconds : Int
+    , nanos : Int
+    }
+
+
+{-| Decodes a Duration from its JSON representation, e.g. `"-1.500s"`.
+-}
+durationDecoder : JD.Decoder Duration
+durationDecoder =
+    JD.string
+        |> JD.andThen (durationFromString >> fromMaybe "could not convert string to duration")
+
+
+{-| Encodes a Duration, with 0, 3, 6 or 9 fractional digits.
+-}
+durationEncoder : Duration -> JE.Value
+durationEncoder v =
+    let
+        sign =
+            if v.seconds < 0 || v.nanos < 0 then
+                "-"
+
+            else
+                ""
+
+        nanos =
+            abs v.nanos
+
+        fraction =
+            if nanos == 0 then
+                ""
+
+            else if modBy 1000000 nanos == 0 then
+                "." ++ String.padLeft 3 '0' (String.fromInt (nanos // 1000000))
+
+            else if modBy 1000 nanos == 0 then
+                "." ++ String.padLeft 6 '0' (String.fromInt (nanos // 1000))
+
+            else
+                "." ++ String.padLeft 9 '0' (String.fromInt nanos)
+    in
+    JE.string <| sign ++ String.fromInt (abs v.seconds) ++ fraction ++ "s"
+
+
+durationFromString : String -> Maybe Duration
+durationFromString s =
+    let
+        ( negate, unsigned ) =
+            if String.startsWith "-" s then
+                ( True, String.dropLeft 1 s )
+
+            else
+                ( False, s )
+
+        digits d =
+            if String.isEmpty d || not (String.all Char.isDigit d) then
+                Nothing
+
+            else
+                String.toInt d
+
+        parts =
+            if String.endsWith "s" unsigned then
+                String.split "." (String.dropRight 1 unsigned)
+
+            else
+                []
+
+        withSign d =
+            if negate then
+                { seconds = -d.seconds, nanos = -d.nanos }
+
+            else
+                d
+
+        -- Durations are limited to approximately +-10,000 years.
+        inRange d =
+            d.seconds <= 315576000000
+    in
+    (case parts of
+        [ seconds ] ->
+            Maybe.map (\sec -> { seconds = sec, nanos = 0 }) (digits seconds)
+
+        [ seconds, fraction ] ->
+            if String.length fraction > 9 then
+                Nothing
+
+            else
+                Maybe.map2 (\sec nanos -> { seconds = sec, nanos = nanos })
+                    (digits seconds)
+                    (digits <| String.padRight 9 '0' fraction)
+
+        _ ->
+            Nothing
+    )
+        |> Maybe.andThen
+            (\d ->
+                if inRange d then
+                    Just (withSign d)
+
+                else
+                    Nothing
+            )
+
+
+{-| FieldMask, as a list of paths made of the original (usually snake\_case) field names, separated
+by dots, e.g. `"address.street_name"`.
+
+When generating code with the `field_mask_paths` option, each message also gets a record with the
+path of each of its fields, e.g. `addressPaths.streetName`.
+
+-}
+type alias FieldMask =
+    List String
+
+
+{-| Decodes a FieldMask from a comma-separated list of lowerCamelCase paths.
+-}
+fieldMaskDecoder : JD.Decoder FieldMask
+fieldMaskDecoder =
+    JD.string
+        |> JD.map
+            (\v ->
+                if String.isEmpty v then
+                    []
+
+                else
+                    List.map camelCaseToSnakeCase <| String.split "," v
+            )
+
+
+{-| Encodes a FieldMask as a comma-separated list of lowerCamelCase paths.
+-}
+fieldMaskEncoder : FieldMask -> JE.Value
+fieldMaskEncoder v =
+    JE.string <| String.join "," <| List.map snakeCaseToCamelCase v
+
+
+snakeCaseToCamelCase : String -> String
+snakeCaseToCamelCase v =
+    case String.split "_" v of
+        first :: rest ->
+            first ++ String.concat (List.map (\w -> String.toUpper (String.left 1 w) ++ String.dropLeft 1 w) rest)
+
+        [] ->
+            v
+
+
+camelCaseToSnakeCase : String -> String
+camelCaseToSnakeCase v =
+    String.foldr
+        (\c acc ->
+            if Char.isUpper c then
+                "_" ++ String.cons (Char.toLower c) acc
+
+            else
+                String.cons c acc
+        )
+        ""
+        v
+
+
+{-| Decodes a google.protobuf.Empty, which is represented as `()`, from any JSON object.
+-}
+unitDecoder : JD.Decoder ()
+unitDecoder =
+    JD.keyValuePairs JD.value
+        |> JD.map (always ())
+
+
+{-| Encodes a google.protobuf.Empty as an empty JSON object.
+-}
+unitEncoder : () -> JE.Value
+unitEncoder _ =
+    JE.object []
+
+
+{-| Any, holding a message of the type identified by `typeUrl`.
+
+The message is kept in its JSON representation, without the `@type` field. It can be converted to
+its Elm type with `anyUnpack`, or with the registry module generated by the `any_registry` option.
+
+-}
+type alias Any =
+    { typeUrl : String
+    , value : JE.Value
+    }
+
+
+{-| Decodes an Any.
+-}
+anyDecoder : JD.Decoder Any
+anyDecoder =
+    JD.map2 Any
+        (JD.field "@type" JD.string)
+        (JD.keyValuePairs JD.value
+            |> JD.map (List.filter (\( key, _ ) -> key /= "@type") >> JE.object)
+        )
+
+
+{-| Encodes an Any.
+-}
+anyEncoder : Any -> JE.Value
+anyEncoder v =
+    JD.decodeValue (JD.keyValuePairs JD.value) v.value
+        |> Result.withDefault []
+        |> (::) ( "@type", JE.string v.typeUrl )
+        |> JE.object
+
+
+{-| Returns the fully-qualified name of the type of the message, i.e. the last segment of the type
+URL.
+-}
+anyTypeName : Any -> String
+anyTypeName v =
+    String.split "/" v.typeUrl
+        |> List.reverse
+        |> List.head
+        |> Maybe.withDefault ""
+
+
+{-| Decodes the message held by an Any.
+-}
+anyUnpack : JD.Decoder a -> Any -> Result JD.Error a
+anyUnpack decoder v =
+    JD.decodeValue decoder v.value
+
+
+{-| Creates an Any from the fully-qualified name of the type of a message and its encoder.
+-}
+anyPack : String -> (a -> JE.Value) -> a -> Any
+anyPack typeName encoder v =
+    { typeUrl = "type.googleapis.com/" ++ typeName
+    , value = encoder v
+    }
+
+
+{-| Decodes a Well Known Type held by an Any, which is stored in its `value` field.
+-}
+anyUnpackWellKnown : JD.Decoder a -> Any -> Result JD.Error a
+anyUnpackWellKnown decoder =
+    anyUnpack (JD.field "value" decoder)
+
+
+{-| Creates an Any holding a Well Known Type, which is stored in its `value` field.
+-}
+anyPackWellKnown : String -> (a -> JE.Value) -> a -> Any
+anyPackWellKnown typeName encoder =
+    anyPack typeName (\v -> JE.object [ ( "value", encoder v ) ])
+
+
+{-| Dynamically typed value, mapped to and from the corresponding JSON value.
+-}
+type Value
+    = NullValue
+    | NumberValue Float
+    | StringValue String
+    | BoolValue Bool
+    | StructValue Struct
+    | ListValue ListValue
+
+
+{-| Decodes a Value.
+-}
+valueDecoder : JD.Decoder Value
+valueDecoder =
+    JD.oneOf
+        [ JD.null NullValue
+        , JD.map NumberValue JD.float
+        , JD.map StringValue JD.string
+        , JD.map BoolValue JD.bool
+        , JD.map StructValue <| JD.lazy <| \_ -> structDecoder
+        , JD.map ListValue <| JD.lazy <| \_ -> listValueDecoder
+        ]
+
+
+{-| Encodes a Value.
+-}
+valueEncoder : Value -> JE.Value
+valueEncoder v =
+    case v of
+        NullValue ->
+            JE.null
+
+        NumberValue n ->
+            JE.float n
+
+        StringValue s ->
+            JE.string s
+
+        BoolValue b ->
+            JE.bool b
+
+        StructValue s ->
+            structEncoder s
+
+        ListValue l ->
+            listValueEncoder l
+
+
+{-| Struct, mapped to and from a JSON object.
+-}
+type alias Struct =
+    Dict.Dict String Value
+
+
+{-| Decodes a Struct.
+-}
+structDecoder : JD.Decoder Struct
+structDecoder =
+    JD.dict <| JD.lazy <| \_ -> valueDecoder
+
+
+{-| Encodes a Struct.
+-}
+structEncoder : Struct -> JE.Value
+structEncoder v =
+    JE.dict identity valueEncoder v
+
+
+{-| ListValue, mapped to and from a JSON array.
+-}
+type alias ListValue =
+    List Value
+
+
+{-| Decodes a ListValue.
+-}
+listValueDecoder : JD.Decoder ListValue
+listValueDecoder =
+    JD.list <| JD.lazy <| \_ -> valueDecoder
+
+
+{-| Encodes a ListValue.
+-}
+listValueEncoder : ListValue -> JE.Value
+listValueEncoder v =
+    JE.list valueEncoder v
+
+
+{-| NullValue, which only has a single value, mapped to and from JSON `null`.
+-}
+type alias NullValue =
+    ()
+
+
+{-| Decodes a NullValue.
+-}
+nullValueDecoder : JD.Decoder NullValue
+nullValueDecoder =
+    JD.null ()
+
+
+{-| Encodes a NullValue.
+-}
+nullValueEncoder : NullValue -> JE.Value
+nullValueEncoder _ =
+    JE.null
+
+
 {-| Turns a Result in to a Decoder
 Taken from <https://github.com/elm-community/json-extra/blob/2.7.0/src/Json/Decode/Extra.elm#L388>
 -}
@@ -250,6 +1189,47 @@ numericStringEncoder =
     String.fromInt >> JE.string
 
 
+{-| Decodes a Float from either a number or a string, including the `"NaN"`, `"Infinity"` and
+`"-Infinity"` strings used by proto3 JSON for values that JSON numbers cannot represent.
+-}
+floatDecoder : JD.Decoder Float
+floatDecoder =
+    let
+        fromString s =
+            case s of
+                "NaN" ->
+                    JD.succeed (0 / 0)
+
+                "Infinity" ->
+                    JD.succeed (1 / 0)
+
+                "-Infinity" ->
+                    JD.succeed (-1 / 0)
+
+                _ ->
+                    fromMaybe "could not convert string to float" (String.toFloat s)
+    in
+    JD.oneOf [ JD.float, JD.string |> JD.andThen fromString ]
+
+
+{-| Encodes a Float as a JSON number, or as one of the `"NaN"`, `"Infinity"` and `"-Infinity"`
+strings for values that JSON numbers cannot represent.
+-}
+floatEncoder : Float -> JE.Value
+floatEncoder v =
+    if isNaN v then
+        JE.string "NaN"
+
+    else if isInfinite v && v > 0 then
+        JE.string "Infinity"
+
+    else if isInfinite v then
+        JE.string "-Infinity"
+
+    else
+        JE.float v
+
+
 {-| Decodes an IntValue.
 -}
 intValueDecoder : JD.Decoder Int
@@ -306,15 +1286,29 @@ bytesValueEncoder =
     bytesFieldEncoder
 
 
+{-| Decodes a BytesValue as `Bytes.Bytes`.
+-}
+elmBytesValueDecoder : JD.Decoder Bytes.Bytes
+elmBytesValueDecoder =
+    elmBytesFieldDecoder
+
+
+{-| Encodes a BytesValue from `Bytes.Bytes`.
+-}
+elmBytesValueEncoder : Bytes.Bytes -> JE.Value
+elmBytesValueEncoder =
+    elmBytesFieldEncoder
+
+
 {-| Decodes a FloatValue.
 -}
 floatValueDecoder : JD.Decoder Float
 floatValueDecoder =
-    JD.float
+    floatDecoder
 
 
 {-| Encodes a FloatValue.
 -}
 floatValueEncoder : Float -> JE.Value
 floatValueEncoder =
-    JE.float
+    floatEncoder
@@ -0,0 +1,95 @@
+module Enums.Strict exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: enums/strict.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type Color
+    = ColorUnspecified -- 0
+    | ColorRed -- 1
+    | ColorGreen -- 2
+
+
+colorDecoder : JD.Decoder Color
+colorDecoder =
+    let
+        lookup s =
+            case s of
+                "COLOR_UNSPECIFIED" ->
+                    JD.succeed ColorUnspecified
+
+                "COLOR_RED" ->
+                    JD.succeed ColorRed
+
+                "COLOR_GREEN" ->
+                    JD.succeed ColorGreen
+
+                "0" ->
+                    JD.succeed ColorUnspecified
+
+                "1" ->
+                    JD.succeed ColorRed
+
+                "2" ->
+                    JD.succeed ColorGreen
+
+                _ ->
+                    JD.fail <| "unknown value for enum Color: " ++ s
+    in
+        enumNameOrNumberDecoder |> JD.andThen lookup
+
+
+colorDefault : Color
+colorDefault = ColorUnspecified
+
+
+colorEncoder : Color -> JE.Value
+colorEncoder v =
+    let
+        lookup s =
+            case s of
+                ColorUnspecified ->
+                    "COLOR_UNSPECIFIED"
+
+                ColorRed ->
+                    "COLOR_RED"
+
+                ColorGreen ->
+                    "COLOR_GREEN"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Palette =
+    { main : Color -- 1
+    , accent : Maybe Color -- 2
+    , others : List Color -- 3
+    }
+
+
+paletteDecoder : JD.Decoder Palette
+paletteDecoder =
+    JD.lazy <| \_ -> decode Palette
+        |> required "main" colorDecoder colorDefault
+        |> optional "accent" colorDecoder
+        |> repeated "others" colorDecoder
+
+
+paletteEncoder : Palette -> JE.Value
+paletteEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "main" colorEncoder colorDefault v.main)
+        , (optionalEncoder "accent" colorEncoder v.accent)
+        , (repeatedFieldEncoder "others" colorEncoder v.others)
+        ]
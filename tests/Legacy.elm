@@ -0,0 +1,85 @@
+module Legacy exposing (..)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: legacy.proto
+
+import Protobuf exposing (..)
+
+import Json.Decode as JD
+import Json.Encode as JE
+
+
+uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+
+
+type Kind
+    = KindOne -- 1
+    | KindTwo -- 2
+
+
+kindDecoder : JD.Decoder Kind
+kindDecoder =
+    let
+        lookup s =
+            case s of
+                "KIND_ONE" ->
+                    JD.succeed KindOne
+
+                "KIND_TWO" ->
+                    JD.succeed KindTwo
+
+                _ ->
+                    JD.fail <| "unknown value for enum Kind: " ++ s
+    in
+        JD.string |> JD.andThen lookup
+
+
+kindDefault : Kind
+kindDefault = KindOne
+
+
+kindEncoder : Kind -> JE.Value
+kindEncoder v =
+    let
+        lookup s =
+            case s of
+                KindOne ->
+                    "KIND_ONE"
+
+                KindTwo ->
+                    "KIND_TWO"
+
+    in
+        JE.string <| lookup v
+
+
+type alias Legacy =
+    { requiredField : Int -- 1
+    , optionalField : Maybe String -- 2
+    , defaultField : Int -- 3
+    , kind : Maybe Kind -- 4
+    , defaultInfinity : Float -- 5
+    }
+
+
+legacyDecoder : JD.Decoder Legacy
+legacyDecoder =
+    JD.lazy <| \_ -> decode Legacy
+        |> requiredStrict "requiredField" intDecoder
+        |> optional "optionalField" JD.string
+        |> required "defaultField" intDecoder 7
+        |> optional "kind" kindDecoder
+        |> required "defaultInfinity" floatDecoder (1 / 0)
+
+
+legacyEncoder : Legacy -> JE.Value
+legacyEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredStrictFieldEncoder "requiredField" JE.int v.requiredField)
+        , (optionalEncoder "optionalField" JE.string v.optionalField)
+        , (requiredFieldEncoder "defaultField" JE.int 7 v.defaultField)
+        , (optionalEncoder "kind" kindEncoder v.kind)
+        , (requiredFieldEncoder "defaultInfinity" floatEncoder (1 / 0) v.defaultInfinity)
+        ]
@@ -1,4 +1,4 @@
-module Main exposing (assertEncodeDecode, decode, emptyJson, encode, foo, fooDefault, fooJson, fuzz, genFuzz, json32numbers, json32strings, json64numbers, json64strings, map, mapJson, msg, msg32, msg64, msgDefault, msgEmpty, msgExtraFieldJson, msgJson, nullJson, oo1Set, oo1SetJson, oo2Set, oo2SetJson, rec1, rec2, recDefault, recJson1, recJson2, suite, timestampFoo, timestampJson, wrappersEmpty, wrappersJsonEmpty, wrappersJsonNull, wrappersJsonSet, wrappersJsonZero, wrappersSet, wrappersZero, wrongTypeJson)
+module Main exposing (anyJson, assertEncodeDecode, bytesFoo, bytesJson, bytesUrlSafeJson, legacy, legacyJson, legacyNegativeInfinity, legacyNegativeInfinityJson, legacyUnknownKindJson, decode, emptyJson, encode, foo, fooDefault, fooJson, fuzz, genFuzz, json32numbers, json32strings, json64numbers, json64strings, map, mapJson, msg, msg32, msg64, msgDefault, msgEmpty, msgExtraFieldJson, msgJson, nullJson, oo1Set, oo1SetJson, oo2Set, oo2SetJson, rec1, rec2, recDefault, recJson1, recJson2, structFoo, structJson, suite, timestampFoo, timestampJson, wrappersEmpty, wrappersJsonEmpty, wrappersJsonNull, wrappersJsonSet, wrappersJsonZero, wrappersSet, wrappersZero, wrongTypeJson)
 
 import Expect exposing (..)
 import Fuzz exposing (..)
@@ -8,6 +8,7 @@ import Integers as I
 import Json.Decode as JD
 import Json.Encode as JE
 import Keywords as K
+import Legacy as L
 import Map as M
 import Protobuf exposing (..)
 import Recursive as R
@@ -19,6 +20,7 @@ import Test exposing (..)
 import Time
 import Wrappers as W
 import Dict
+import Enums.Strict as E
 
 
 suite : Test
@@ -40,8 +42,7 @@ suite =
         , test "JSON decode numbers to 64-bit ints" <| \() -> decode I.sixtyFourDecoder json64numbers |> equal (Ok msg64)
         , test "JSON decode numeric strings to 64-bit ints" <| \() -> decode I.sixtyFourDecoder json64strings |> equal (Ok msg64)
 
-        -- TODO: Should fail.
-        , test "JSON decode wrong type" <| \() -> decode T.simpleDecoder wrongTypeJson |> equal (Ok msgDefault)
+        , test "JSON decode wrong type" <| \() -> decode T.simpleDecoder wrongTypeJson |> Result.toMaybe |> equal Nothing
         , test "JSON decode null" <| \() -> decode T.simpleDecoder nullJson |> equal (Ok msgDefault)
         , describe "oneof"
             [ test "encode" <| \() -> encode T.fooEncoder foo |> equal fooJson
@@ -62,6 +63,48 @@ suite =
             [ test "encode" <| \() -> encode T.fooEncoder timestampFoo |> equal timestampJson
             , test "decode" <| \() -> decode T.fooDecoder timestampJson |> equal (Ok timestampFoo)
             ]
+        , describe "duration"
+            [ test "decode" <| \() -> decode durationDecoder "\"1.5s\"" |> equal (Ok { seconds = 1, nanos = 500000000 })
+            , test "decode negative" <| \() -> decode durationDecoder "\"-0.000000001s\"" |> equal (Ok { seconds = 0, nanos = -1 })
+            , test "decode invalid" <| \() -> decode durationDecoder "\"1.5\"" |> Result.toMaybe |> equal Nothing
+            , test "encode" <| \() -> encode durationEncoder { seconds = 1, nanos = 500000000 } |> equal "\"1.500s\""
+            , test "encode negative" <| \() -> encode durationEncoder { seconds = -3, nanos = -1000 } |> equal "\"-3.000001s\""
+            , test "encode whole seconds" <| \() -> encode durationEncoder { seconds = 60, nanos = 0 } |> equal "\"60s\""
+            ]
+        , describe "struct"
+            [ test "decode" <| \() -> decode structDecoder structJson |> equal (Ok structFoo)
+            , test "encode" <| \() -> encode structEncoder structFoo |> equal structJson
+            , test "decode null value" <| \() -> decode valueDecoder "null" |> equal (Ok NullValue)
+            ]
+        , describe "field mask"
+            [ test "decode" <| \() -> decode fieldMaskDecoder "\"userId,address.streetName\"" |> equal (Ok [ "user_id", "address.street_name" ])
+            , test "decode empty" <| \() -> decode fieldMaskDecoder "\"\"" |> equal (Ok [])
+            , test "encode" <| \() -> encode fieldMaskEncoder [ "user_id", "address.street_name" ] |> equal "\"userId,address.streetName\""
+            ]
+        , describe "empty"
+            [ test "decode" <| \() -> decode unitDecoder "{\"unknown\": 1}" |> equal (Ok ())
+            , test "encode" <| \() -> encode unitEncoder () |> equal "{}"
+            ]
+        , describe "any"
+            [ test "type name" <| \() -> decode anyDecoder anyJson |> Result.map anyTypeName |> equal (Ok "google.protobuf.Duration")
+            , test "unpack well known type" <| \() -> decode anyDecoder anyJson |> Result.toMaybe |> Maybe.andThen (anyUnpackWellKnown durationDecoder >> Result.toMaybe) |> equal (Just { seconds = 1, nanos = 0 })
+            , test "encode" <| \() -> encode anyEncoder (anyPackWellKnown "google.protobuf.Duration" durationEncoder { seconds = 1, nanos = 0 }) |> equal anyJson
+            ]
+        , describe "int64"
+            [ test "decode max uint64" <| \() -> decode uint64Decoder "\"18446744073709551615\"" |> Result.map uint64ToString |> equal (Ok "18446744073709551615")
+            , test "encode max uint64" <| \() -> uint64FromString "18446744073709551615" |> Maybe.map (encode uint64Encoder) |> equal (Just "\"18446744073709551615\"")
+            , test "uint64 overflow" <| \() -> uint64FromString "18446744073709551616" |> equal Nothing
+            , test "negative uint64" <| \() -> uint64FromString "-1" |> equal Nothing
+            , test "min int64" <| \() -> int64FromString "-9223372036854775808" |> Maybe.map int64ToString |> equal (Just "-9223372036854775808")
+            , test "int64 overflow" <| \() -> int64FromString "9223372036854775808" |> equal Nothing
+            , test "decode int64 number" <| \() -> decode int64Decoder "-123" |> equal (Ok (int64FromInt -123))
+            , test "canonical int64" <| \() -> int64FromString "-007" |> equal (int64FromString "-7")
+            ]
+        , describe "bytes"
+            [ test "encode" <| \() -> encode T.fooEncoder bytesFoo |> equal bytesJson
+            , test "decode" <| \() -> decode T.fooDecoder bytesJson |> equal (Ok bytesFoo)
+            , test "decode URL-safe" <| \() -> decode T.fooDecoder bytesUrlSafeJson |> equal (Ok bytesFoo)
+            ]
         , describe "wrappers"
             -- TODO: Preserve nulls.
             [ test "encodeEmpty" <| \() -> encode W.wrappersEncoder wrappersEmpty |> equal wrappersJsonEmpty
@@ -71,6 +114,20 @@ suite =
                 , test "Set" <| \() -> decode W.wrappersDecoder wrappersJsonSet |> equal (Ok wrappersSet)
                 ]
             ]
+        , describe "proto2"
+            [ test "encode" <| \() -> encode L.legacyEncoder legacy |> equal legacyJson
+            , test "decode" <| \() -> decode L.legacyDecoder legacyJson |> equal (Ok legacy)
+            , test "decode missing required field" <| \() -> decode L.legacyDecoder emptyJson |> Result.toMaybe |> equal Nothing
+            , test "decode unknown closed enum value" <| \() -> decode L.legacyDecoder legacyUnknownKindJson |> Result.toMaybe |> equal Nothing
+            , test "encode infinity" <| \() -> encode L.legacyEncoder legacyNegativeInfinity |> equal legacyNegativeInfinityJson
+            , test "decode infinity" <| \() -> decode L.legacyDecoder legacyNegativeInfinityJson |> equal (Ok legacyNegativeInfinity)
+            ]
+        , describe "unknown enum values"
+            [ test "decode known values" <| \() -> decode E.paletteDecoder "{\"main\": \"COLOR_RED\", \"accent\": 2, \"others\": [\"COLOR_GREEN\"]}" |> equal (Ok { main = E.ColorRed, accent = Just E.ColorGreen, others = [ E.ColorGreen ] })
+            , test "decode unknown value" <| \() -> decode E.paletteDecoder "{\"main\": \"COLOR_BLUE\"}" |> Result.toMaybe |> equal Nothing
+            , test "decode unknown optional value" <| \() -> decode E.paletteDecoder "{\"accent\": 3}" |> Result.toMaybe |> equal Nothing
+            , test "decode unknown repeated value" <| \() -> decode E.paletteDecoder "{\"others\": [\"COLOR_RED\", \"COLOR_BLUE\"]}" |> Result.toMaybe |> equal Nothing
+            ]
         , describe "encode / decode"
             [ fuzz (map5 genFuzz string int (maybe string) (maybe int) (maybe int)) "fuzzer" <|
                 assertEncodeDecode F.fuzzEncoder F.fuzzDecoder
@@ -140,7 +197,7 @@ fooDefault =
     , colours = []
     , singleIntField = 0
     , repeatedIntField = []
-    , oo = T.OoUnspecified
+    , oo = T.Foo_OoUnspecified
     , bytesField = []
     , stringValueField = Nothing
     , otherField = Nothing
@@ -152,7 +209,7 @@ fooDefault =
 recDefault : R.Rec
 recDefault =
     { int32Field = 0
-    , r = R.RUnspecified
+    , r = R.Rec_RUnspecified
     , stringField = ""
     }
 
@@ -205,7 +262,7 @@ foo =
         , 222
         , 333
         ]
-    , oo = T.Oo1 1
+    , oo = T.Foo_Oo1 1
     , bytesField = []
     , stringValueField = Nothing
     , otherField =
@@ -278,7 +335,7 @@ wrongTypeJson =
 oo1Set : T.Foo
 oo1Set =
     { fooDefault
-        | oo = T.Oo1 123
+        | oo = T.Foo_Oo1 123
     }
 
 
@@ -294,7 +351,7 @@ oo1SetJson =
 oo2Set : T.Foo
 oo2Set =
     { fooDefault
-        | oo = T.Oo2 True
+        | oo = T.Foo_Oo2 True
     }
 
 
@@ -320,9 +377,9 @@ rec1 : R.Rec
 rec1 =
     { int32Field = 0
     , r =
-        R.RecField
+        R.Rec_RecField
             { int32Field = 0
-            , r = R.RUnspecified
+            , r = R.Rec_RUnspecified
             , stringField = ""
             }
     , stringField = ""
@@ -344,12 +401,12 @@ rec2 : R.Rec
 rec2 =
     { int32Field = 0
     , r =
-        R.RecField
+        R.Rec_RecField
             { int32Field = 0
             , r =
-                R.RecField
+                R.Rec_RecField
                     { int32Field = 0
-                    , r = R.RUnspecified
+                    , r = R.Rec_RUnspecified
                     , stringField = ""
                     }
             , stringField = ""
@@ -375,6 +432,31 @@ timestampFoo =
     }
 
 
+bytesFoo : T.Foo
+bytesFoo =
+    { fooDefault
+        | bytesField = [ 0, 1, 254, 255 ]
+    }
+
+
+bytesJson : String
+bytesJson =
+    String.trim """
+{
+  "bytesField": "AAH+/w=="
+}
+"""
+
+
+bytesUrlSafeJson : String
+bytesUrlSafeJson =
+    String.trim """
+{
+  "bytesField": "AAH-_w"
+}
+"""
+
+
 wrappersJsonEmpty : String
 wrappersJsonEmpty =
     String.trim """
@@ -475,6 +557,39 @@ wrappersSet =
     }
 
 
+anyJson : String
+anyJson =
+    String.trim """
+{
+  "@type": "type.googleapis.com/google.protobuf.Duration",
+  "value": "1s"
+}
+"""
+
+
+structFoo : Struct
+structFoo =
+    Dict.fromList
+        [ ( "list", ListValue [ NumberValue 1, StringValue "x", BoolValue True, NullValue ] )
+        , ( "nested", StructValue Dict.empty )
+        ]
+
+
+structJson : String
+structJson =
+    String.trim """
+{
+  "list": [
+    1,
+    "x",
+    true,
+    null
+  ],
+  "nested": {}
+}
+"""
+
+
 map : M.MessageWithMaps
 map =
     { stringToMessages = Dict.fromList 
@@ -485,7 +600,13 @@ map =
         [
             ("k1", "v1"),
             ("k2", "v2")
-        ]
+        ],
+        intToStrings = Dict.fromList
+        [
+            (2, "two"),
+            (10, "ten")
+        ],
+        boolToStrings = { whenTrue = Just "yes", whenFalse = Nothing }
     }
 
 
@@ -502,6 +623,13 @@ mapJson =
   "stringToStrings": {
     "k1": "v1",
     "k2": "v2"
+  },
+  "intToStrings": {
+    "10": "ten",
+    "2": "two"
+  },
+  "boolToStrings": {
+    "true": "yes"
   }
 }
 """
@@ -577,3 +705,49 @@ json64strings =
   "sfixed64Field": "-903"
 }
 """
+
+
+legacy : L.Legacy
+legacy =
+    { requiredField = 1
+    , optionalField = Nothing
+    , defaultField = 7
+    , kind = Nothing
+    , defaultInfinity = 1 / 0
+    }
+
+
+legacyNegativeInfinity : L.Legacy
+legacyNegativeInfinity =
+    { legacy
+        | defaultInfinity = -1 / 0
+    }
+
+
+legacyNegativeInfinityJson : String
+legacyNegativeInfinityJson =
+    String.trim """
+{
+  "requiredField": 1,
+  "defaultInfinity": "-Infinity"
+}
+"""
+
+
+legacyUnknownKindJson : String
+legacyUnknownKindJson =
+    String.trim """
+{
+  "requiredField": 1,
+  "kind": "KIND_THREE"
+}
+"""
+
+
+legacyJson : String
+legacyJson =
+    String.trim """
+{
+  "requiredField": 1
+}
+"""
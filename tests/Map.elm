@@ -36,6 +36,8 @@ mapValueEncoder v =
 type alias MessageWithMaps =
     { stringToMessages : Dict.Dict String MapValue -- 8
     , stringToStrings : Dict.Dict String String -- 7
+    , intToStrings : Dict.Dict Int String -- 9
+    , boolToStrings : BoolDict String -- 10
     }
 
 
@@ -44,6 +46,8 @@ messageWithMapsDecoder =
     JD.lazy <| \_ -> decode MessageWithMaps
         |> mapEntries "stringToMessages" mapValueDecoder
         |> mapEntries "stringToStrings" JD.string
+        |> keyedMapEntries "intToStrings" String.toInt JD.string
+        |> boolMapEntries "boolToStrings" JD.string
 
 
 messageWithMapsEncoder : MessageWithMaps -> JE.Value
@@ -51,6 +55,8 @@ messageWithMapsEncoder v =
     JE.object <| List.filterMap identity <|
         [ (mapEntriesFieldEncoder "stringToMessages" mapValueEncoder v.stringToMessages)
         , (mapEntriesFieldEncoder "stringToStrings" JE.string v.stringToStrings)
+        , (keyedMapEntriesFieldEncoder "intToStrings" String.fromInt JE.string v.intToStrings)
+        , (boolMapEntriesFieldEncoder "boolToStrings" JE.string v.boolToStrings)
         ]
 
 
@@ -94,3 +100,45 @@ messageWithMaps_StringToStringsEntryEncoder v =
         [ (requiredFieldEncoder "key" JE.string "" v.key)
         , (requiredFieldEncoder "value" JE.string "" v.value)
         ]
+
+
+type alias MessageWithMaps_IntToStringsEntry =
+    { key : Int -- 1
+    , value : String -- 2
+    }
+
+
+messageWithMaps_IntToStringsEntryDecoder : JD.Decoder MessageWithMaps_IntToStringsEntry
+messageWithMaps_IntToStringsEntryDecoder =
+    JD.lazy <| \_ -> decode MessageWithMaps_IntToStringsEntry
+        |> required "key" intDecoder 0
+        |> required "value" JD.string ""
+
+
+messageWithMaps_IntToStringsEntryEncoder : MessageWithMaps_IntToStringsEntry -> JE.Value
+messageWithMaps_IntToStringsEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.int 0 v.key)
+        , (requiredFieldEncoder "value" JE.string "" v.value)
+        ]
+
+
+type alias MessageWithMaps_BoolToStringsEntry =
+    { key : Bool -- 1
+    , value : String -- 2
+    }
+
+
+messageWithMaps_BoolToStringsEntryDecoder : JD.Decoder MessageWithMaps_BoolToStringsEntry
+messageWithMaps_BoolToStringsEntryDecoder =
+    JD.lazy <| \_ -> decode MessageWithMaps_BoolToStringsEntry
+        |> required "key" JD.bool False
+        |> required "value" JD.string ""
+
+
+messageWithMaps_BoolToStringsEntryEncoder : MessageWithMaps_BoolToStringsEntry -> JE.Value
+messageWithMaps_BoolToStringsEntryEncoder v =
+    JE.object <| List.filterMap identity <|
+        [ (requiredFieldEncoder "key" JE.bool False v.key)
+        , (requiredFieldEncoder "value" JE.string "" v.value)
+        ]
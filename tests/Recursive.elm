@@ -17,29 +17,29 @@ uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
 type alias Rec =
     { int32Field : Int -- 1
     , stringField : String -- 4
-    , r : R
+    , r : Rec_R
     }
 
 
-type R
-    = RUnspecified
-    | RecField Rec
+type Rec_R
+    = Rec_RUnspecified
+    | Rec_RecField Rec
 
 
-rDecoder : JD.Decoder R
-rDecoder =
+rec_RDecoder : JD.Decoder Rec_R
+rec_RDecoder =
     JD.lazy <| \_ -> JD.oneOf
-        [ JD.map RecField (JD.field "recField" recDecoder)
-        , JD.succeed RUnspecified
+        [ JD.map Rec_RecField (JD.field "recField" recDecoder)
+        , JD.succeed Rec_RUnspecified
         ]
 
 
-rEncoder : R -> Maybe ( String, JE.Value )
-rEncoder v =
+rec_REncoder : Rec_R -> Maybe ( String, JE.Value )
+rec_REncoder v =
     case v of
-        RUnspecified ->
+        Rec_RUnspecified ->
             Nothing
-        RecField x ->
+        Rec_RecField x ->
             Just ( "recField", recEncoder x )
 
 
@@ -48,7 +48,7 @@ recDecoder =
     JD.lazy <| \_ -> decode Rec
         |> required "int32Field" intDecoder 0
         |> required "stringField" JD.string ""
-        |> field rDecoder
+        |> field rec_RDecoder
 
 
 recEncoder : Rec -> JE.Value
@@ -56,5 +56,5 @@ recEncoder v =
     JE.object <| List.filterMap identity <|
         [ (requiredFieldEncoder "int32Field" JE.int 0 v.int32Field)
         , (requiredFieldEncoder "stringField" JE.string "" v.stringField)
-        , (rEncoder v.r)
+        , (rec_REncoder v.r)
         ]
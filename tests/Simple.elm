@@ -9,8 +9,8 @@ import Protobuf exposing (..)
 
 import Json.Decode as JD
 import Json.Encode as JE
-import Dir.Other_dir exposing (..)
-import Other exposing (..)
+import Dir.Other_dir
+import Other
 
 
 uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
@@ -115,36 +115,36 @@ type alias Foo =
     , repeatedIntField : List Int -- 6
     , bytesField : Bytes -- 9
     , stringValueField : Maybe String -- 10
-    , otherField : Maybe Other -- 11
-    , otherDirField : Maybe OtherDir -- 12
+    , otherField : Maybe Other.Other -- 11
+    , otherDirField : Maybe Dir.Other_dir.OtherDir -- 12
     , timestampField : Maybe Timestamp -- 13
-    , oo : Oo
+    , oo : Foo_Oo
     }
 
 
-type Oo
-    = OoUnspecified
-    | Oo1 Int
-    | Oo2 Bool
+type Foo_Oo
+    = Foo_OoUnspecified
+    | Foo_Oo1 Int
+    | Foo_Oo2 Bool
 
 
-ooDecoder : JD.Decoder Oo
-ooDecoder =
+foo_OoDecoder : JD.Decoder Foo_Oo
+foo_OoDecoder =
     JD.lazy <| \_ -> JD.oneOf
-        [ JD.map Oo1 (JD.field "oo1" intDecoder)
-        , JD.map Oo2 (JD.field "oo2" JD.bool)
-        , JD.succeed OoUnspecified
+        [ JD.map Foo_Oo1 (JD.field "oo1" intDecoder)
+        , JD.map Foo_Oo2 (JD.field "oo2" JD.bool)
+        , JD.succeed Foo_OoUnspecified
         ]
 
 
-ooEncoder : Oo -> Maybe ( String, JE.Value )
-ooEncoder v =
+foo_OoEncoder : Foo_Oo -> Maybe ( String, JE.Value )
+foo_OoEncoder v =
     case v of
-        OoUnspecified ->
+        Foo_OoUnspecified ->
             Nothing
-        Oo1 x ->
+        Foo_Oo1 x ->
             Just ( "oo1", JE.int x )
-        Oo2 x ->
+        Foo_Oo2 x ->
             Just ( "oo2", JE.bool x )
 
 
@@ -159,10 +159,10 @@ fooDecoder =
         |> repeated "repeatedIntField" intDecoder
         |> required "bytesField" bytesFieldDecoder []
         |> optional "stringValueField" stringValueDecoder
-        |> optional "otherField" otherDecoder
-        |> optional "otherDirField" otherDirDecoder
+        |> optional "otherField" Other.otherDecoder
+        |> optional "otherDirField" Dir.Other_dir.otherDirDecoder
         |> optional "timestampField" timestampDecoder
-        |> field ooDecoder
+        |> field foo_OoDecoder
 
 
 fooEncoder : Foo -> JE.Value
@@ -176,8 +176,8 @@ fooEncoder v =
         , (repeatedFieldEncoder "repeatedIntField" JE.int v.repeatedIntField)
         , (requiredFieldEncoder "bytesField" bytesFieldEncoder [] v.bytesField)
         , (optionalEncoder "stringValueField" stringValueEncoder v.stringValueField)
-        , (optionalEncoder "otherField" otherEncoder v.otherField)
-        , (optionalEncoder "otherDirField" otherDirEncoder v.otherDirField)
+        , (optionalEncoder "otherField" Other.otherEncoder v.otherField)
+        , (optionalEncoder "otherDirField" Dir.Other_dir.otherDirEncoder v.otherDirField)
         , (optionalEncoder "timestampField" timestampEncoder v.timestampField)
-        , (ooEncoder v.oo)
+        , (foo_OoEncoder v.oo)
         ]